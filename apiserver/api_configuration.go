@@ -72,6 +72,11 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs",
 			c.PostConfiguration,
 		},
+		"PostFilterPreview": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/filter-preview",
+			c.PostFilterPreview,
+		},
 		"PutConfigurationById": Route{
 			strings.ToUpper("Put"),
 			"/v1/configs/{config-id}",
@@ -161,6 +166,42 @@ func (c *ConfigurationAPIController) PostConfiguration(w http.ResponseWriter, r
 	EncodeJSONResponse(result.Body, &result.Code, w)
 }
 
+// PostFilterPreview - Previews an asset filter
+func (c *ConfigurationAPIController) PostFilterPreview(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var assetFilterParam [][]FilterRule
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&assetFilterParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertRecurseInterfaceRequired(assetFilterParam, AssertFilterRuleRequired); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertRecurseInterfaceRequired(assetFilterParam, AssertFilterRuleConstraints); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostFilterPreview(r.Context(), configIdParam, assetFilterParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
 // PutConfigurationById - Updates a configuration
 func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
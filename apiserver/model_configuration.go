@@ -30,6 +30,36 @@ type Configuration struct {
 	// Timeout in seconds
 	RequestTimeout *int32 `json:"requestTimeout,omitempty"`
 
+	// Number of retries for requests failing temporarily (e.g. status 429 or 5xx)
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Maximum number of requests per minute sent to the GP Joule API. 0 means no limit.
+	RateLimit *int32 `json:"rateLimit,omitempty"`
+
+	// Time in seconds a charge point may be offline before an alarm is raised
+	OfflineThreshold *int32 `json:"offlineThreshold,omitempty"`
+
+	// Time in seconds sessions are read again before the latest session sent, so sessions reported late are not missed
+	SessionOverlap *int32 `json:"sessionOverlap,omitempty"`
+
+	// Handling of assets that disappeared from GP Joule: `inactive` keeps them in place, `archive` moves them to an archive asset, `delete` deletes them once they are missing for 24 hours
+	RemovalPolicy *string `json:"removalPolicy,omitempty"`
+
+	// Templates for the asset names by asset kind, e.g. `{"connector": "{{evse_id}}"}`. Placeholders are written as `{{key}}`.
+	NameTemplates map[string]string `json:"nameTemplates,omitempty"`
+
+	// Flag to rename existing assets if their name according to the name templates changes
+	RenameAssets *bool `json:"renameAssets,omitempty"`
+
+	// Mapping of clusters to Eliona projects. Clusters not matched by any mapping are created in the projects of `projectIDs`.
+	ProjectMappings []ProjectMapping `json:"projectMappings,omitempty"`
+
+	// Entries overriding or extending the built-in catalogue of error codes
+	ErrorCodes []ErrorCode `json:"errorCodes,omitempty"`
+
+	// Language of the error messages: `de`, `en`, `fr` or `it`
+	ErrorLanguage *string `json:"errorLanguage,omitempty"`
+
 	// Array of rules combined by logical OR
 	AssetFilter [][]FilterRule `json:"assetFilter,omitempty"`
 
@@ -45,6 +75,16 @@ type Configuration struct {
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
 func AssertConfigurationRequired(obj Configuration) error {
+	for _, el := range obj.ProjectMappings {
+		if err := AssertProjectMappingRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.ErrorCodes {
+		if err := AssertErrorCodeRequired(el); err != nil {
+			return err
+		}
+	}
 	if err := AssertRecurseInterfaceRequired(obj.AssetFilter, AssertFilterRuleRequired); err != nil {
 		return err
 	}
@@ -53,5 +93,10 @@ func AssertConfigurationRequired(obj Configuration) error {
 
 // AssertConfigurationConstraints checks if the values respects the defined constraints
 func AssertConfigurationConstraints(obj Configuration) error {
+	for _, el := range obj.ErrorCodes {
+		if err := AssertErrorCodeConstraints(el); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,42 @@
+/*
+ * GP Joule app API
+ *
+ * API to access and configure the GP Joule app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ErrorCode - Entry of the error code catalogue. Entries override the built-in entry with the same code.
+type ErrorCode struct {
+
+	// OCPP error code or vendor code reported by GP Joule
+	Code string `json:"code"`
+
+	// Text of the error by language: `de`, `en`, `fr` or `it`
+	Translation map[string]string `json:"translation,omitempty"`
+
+	// Severity of the error deciding the priority of its alarm: `high`, `medium` or `low`
+	Severity string `json:"severity,omitempty"`
+}
+
+// AssertErrorCodeRequired checks if the required fields are not zero-ed
+func AssertErrorCodeRequired(obj ErrorCode) error {
+	elements := map[string]interface{}{
+		"code": obj.Code,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertErrorCodeConstraints checks if the values respects the defined constraints
+func AssertErrorCodeConstraints(obj ErrorCode) error {
+	return nil
+}
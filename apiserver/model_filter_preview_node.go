@@ -0,0 +1,55 @@
+/*
+ * GP Joule app API
+ *
+ * API to access and configure the GP Joule app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// FilterPreviewNode - Cluster, charge point or connector read from GP Joule, marked whether it adheres to the filter
+type FilterPreviewNode struct {
+
+	// Kind of the node: `cluster`, `charge_point` or `connector`
+	Kind string `json:"kind"`
+
+	// Identifier in GP Joule (the name for clusters)
+	Id string `json:"id"`
+
+	// Name in GP Joule (the EVSE ID for connectors)
+	Name string `json:"name"`
+
+	// Flag whether an asset is created for the node
+	Included bool `json:"included"`
+
+	// Charge points of a cluster or connectors of a charge point
+	Children []FilterPreviewNode `json:"children,omitempty"`
+}
+
+// AssertFilterPreviewNodeRequired checks if the required fields are not zero-ed
+func AssertFilterPreviewNodeRequired(obj FilterPreviewNode) error {
+	elements := map[string]interface{}{
+		"kind": obj.Kind,
+		"id":   obj.Id,
+		"name": obj.Name,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.Children {
+		if err := AssertFilterPreviewNodeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertFilterPreviewNodeConstraints checks if the values respects the defined constraints
+func AssertFilterPreviewNodeConstraints(obj FilterPreviewNode) error {
+	return nil
+}
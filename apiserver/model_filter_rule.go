@@ -13,7 +13,19 @@ package apiserver
 type FilterRule struct {
 	Parameter string `json:"parameter,omitempty"`
 
+	// Comparison of the parameter: `regex` (default), `eq`, `gt`, `gte`, `lt`, `lte` or `in`
+	Operator string `json:"operator,omitempty"`
+
 	Regex string `json:"regex,omitempty"`
+
+	// Value compared by `eq`, `gt`, `gte`, `lt` and `lte`. Numbers are compared by their value.
+	Value string `json:"value,omitempty"`
+
+	// Set of values for `in`
+	Values []string `json:"values,omitempty"`
+
+	// Flag to invert the result of the rule
+	Negate bool `json:"negate,omitempty"`
 }
 
 // AssertFilterRuleRequired checks if the required fields are not zero-ed
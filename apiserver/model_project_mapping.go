@@ -0,0 +1,43 @@
+/*
+ * GP Joule app API
+ *
+ * API to access and configure the GP Joule app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ProjectMapping - Mapping of clusters to Eliona projects
+type ProjectMapping struct {
+
+	// Array of rules combined by logical OR selecting the clusters, e.g. by `name`
+	Filter [][]FilterRule `json:"filter"`
+
+	// List of Eliona project ids the selected clusters are created in
+	ProjectIDs []string `json:"projectIDs"`
+}
+
+// AssertProjectMappingRequired checks if the required fields are not zero-ed
+func AssertProjectMappingRequired(obj ProjectMapping) error {
+	elements := map[string]interface{}{
+		"filter":     obj.Filter,
+		"projectIDs": obj.ProjectIDs,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	if err := AssertRecurseInterfaceRequired(obj.Filter, AssertFilterRuleRequired); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AssertProjectMappingConstraints checks if the values respects the defined constraints
+func AssertProjectMappingConstraints(obj ProjectMapping) error {
+	return nil
+}
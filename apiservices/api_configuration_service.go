@@ -20,6 +20,8 @@ import (
 	"errors"
 	"gp-joule/apiserver"
 	"gp-joule/conf"
+	"gp-joule/gp_joule"
+	"gp-joule/model"
 	"net/http"
 )
 
@@ -44,6 +46,9 @@ func (s *ConfigurationAPIService) GetConfigurations(ctx context.Context) (apiser
 
 func (s *ConfigurationAPIService) PostConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
 	insertedConfig, err := conf.InsertConfig(ctx, config)
+	if errors.Is(err, conf.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest, Body: err.Error()}, nil
+	}
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
@@ -64,6 +69,9 @@ func (s *ConfigurationAPIService) GetConfigurationById(ctx context.Context, conf
 func (s *ConfigurationAPIService) PutConfigurationById(ctx context.Context, configId int64, config apiserver.Configuration) (apiserver.ImplResponse, error) {
 	config.Id = &configId
 	upsertedConfig, err := conf.UpsertConfig(ctx, config)
+	if errors.Is(err, conf.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest, Body: err.Error()}, nil
+	}
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
@@ -80,3 +88,29 @@ func (s *ConfigurationAPIService) DeleteConfigurationById(ctx context.Context, c
 	}
 	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
 }
+
+func (s *ConfigurationAPIService) PostFilterPreview(ctx context.Context, configId int64, filter [][]apiserver.FilterRule) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if errors.Is(err, conf.ErrBadRequest) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if filter == nil {
+		filter = config.AssetFilter
+	}
+	if err := conf.ValidateFilter(filter); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest, Body: err.Error()}, nil
+	}
+
+	clusters, err := gp_joule.NewPreviewClient(config).GetClusters()
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadGateway, Body: err.Error()}, nil
+	}
+	nodes, err := model.PreviewFilter(clusters, filter)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, nodes), nil
+}
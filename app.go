@@ -27,10 +27,13 @@ import (
 	"gp-joule/model"
 	"math"
 	"net/http"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
@@ -56,6 +59,93 @@ func initialization() {
 		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
 		dashboard.InitWidgetTypeFiles("resources/widget-types/*.json"),
 	)
+
+	// Patch the app to v1.1.0
+	app.Patch(conn, app.AppName(), "010100",
+		app.ExecSqlFile("conf/v1.1.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.2.0
+	app.Patch(conn, app.AppName(), "010200",
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.3.0
+	app.Patch(conn, app.AppName(), "010300",
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.4.0
+	app.Patch(conn, app.AppName(), "010400",
+		app.ExecSqlFile("conf/v1.4.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.5.0
+	app.Patch(conn, app.AppName(), "010500",
+		app.ExecSqlFile("conf/v1.5.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.6.0
+	app.Patch(conn, app.AppName(), "010600",
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.7.0
+	app.Patch(conn, app.AppName(), "010700",
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.8.0
+	app.Patch(conn, app.AppName(), "010800",
+		app.ExecSqlFile("conf/v1.8.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.9.0
+	app.Patch(conn, app.AppName(), "010900",
+		app.ExecSqlFile("conf/v1.9.0.sql"),
+	)
+
+	// Patch the app to v1.10.0
+	app.Patch(conn, app.AppName(), "011000",
+		app.ExecSqlFile("conf/v1.10.0.sql"),
+	)
+
+	// Patch the app to v1.11.0
+	app.Patch(conn, app.AppName(), "011100",
+		app.ExecSqlFile("conf/v1.11.0.sql"),
+	)
+
+	// Patch the app to v1.12.0
+	app.Patch(conn, app.AppName(), "011200",
+		app.ExecSqlFile("conf/v1.12.0.sql"),
+	)
+
+	// Patch the app to v1.13.0
+	app.Patch(conn, app.AppName(), "011300",
+		app.ExecSqlFile("conf/v1.13.0.sql"),
+	)
+
+	// Patch the app to v1.14.0
+	app.Patch(conn, app.AppName(), "011400",
+		app.ExecSqlFile("conf/v1.14.0.sql"),
+	)
+
+	// Patch the app to v1.15.0
+	app.Patch(conn, app.AppName(), "011500",
+		app.ExecSqlFile("conf/v1.15.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+	)
+
+	// Patch the app to v1.16.0
+	app.Patch(conn, app.AppName(), "011600",
+		app.ExecSqlFile("conf/v1.16.0.sql"),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+		eliona.RecordAssetNames,
+	)
 }
 
 var once sync.Once
@@ -88,24 +178,32 @@ func collectData() {
 				"Enable: %t\n"+
 				"Refresh Interval: %d\n"+
 				"Request Timeout: %d\n"+
+				"Max Retries: %d\n"+
+				"Rate Limit: %d\n"+
+				"Offline Threshold: %d\n"+
 				"Project IDs: %v\n",
 				*config.Id,
 				*config.Enable,
 				config.RefreshInterval,
 				*config.RequestTimeout,
+				*config.MaxRetries,
+				*config.RateLimit,
+				*config.OfflineThreshold,
 				*config.ProjectIDs)
 		}
 
 		common.RunOnceWithParam(func(config apiserver.Configuration) {
 
 			log.Info("main", "Collecting for config %d started.", *config.Id)
-			if err := collectResources(&config); err != nil {
+			client := gp_joule.NewClient(&config)
+			clusters, err := collectResources(&config, client)
+			if err != nil {
 				return // ErrorNotification is handled in the method itself.
 			}
-			if err := sendSessions(&config); err != nil {
+			if err := sendSessions(&config, client, dbStore{}, clusters); err != nil {
 				return // ErrorNotification is handled in the method itself.
 			}
-			if err := sendErrors(&config); err != nil {
+			if err := sendErrors(&config, client, dbStore{}, clusters); err != nil {
 				return // ErrorNotification is handled in the method itself.
 			}
 			log.Info("main", "Collecting for config %d finished.", *config.Id)
@@ -115,42 +213,121 @@ func collectData() {
 	}
 }
 
-func collectResources(config *apiserver.Configuration) error {
+// collectResources creates and updates the assets for the clusters adhering to the asset filter and returns
+// these clusters.
+func collectResources(config *apiserver.Configuration, client gp_joule.Client) ([]*model.Cluster, error) {
 
 	// check if project ids are defined, warn if not
-	if config.ProjectIDs == nil || len(*config.ProjectIDs) == 0 {
+	if len(conf.ProjIds(*config)) == 0 && len(config.ProjectMappings) == 0 {
 		log.Warn("api", "No project IDs defined in config %d", *config.Id)
-		return nil
+		return nil, nil
 	}
 
 	// get all clusters from GP Joule API
-	clusters, err := gp_joule.GetClusters(config)
+	clusters, err := client.GetClusters()
 	if err != nil {
 		log.Error("api", "ErrorNotification collecting clusters: %v", err)
-		return err
+		return nil, err
 	}
 	log.Trace("api", "Clusters: %v", clusters)
 
+	// keep connector names stable
+	assignedConnectors, err := assignConnectorIndices(config, clusters)
+	if err != nil {
+		log.Error("eliona", "Error assigning connector indices for config %d: %v", *config.Id, err)
+		return nil, err
+	}
+
+	// skip everything not adhering to the asset filter
+	filteredClusters, err := model.FilterClusters(clusters, config.AssetFilter)
+	if err != nil {
+		log.Error("eliona", "Error filtering clusters for config %d: %v", *config.Id, err)
+		return nil, err
+	}
+
+	// assign clusters to projects
+	projectIds, clustersByProject, err := mapClustersToProjects(config, filteredClusters)
+	if err != nil {
+		log.Error("eliona", "Error mapping clusters to projects for config %d: %v", *config.Id, err)
+		return nil, err
+	}
+
+	// assign all clusters to projects as well, assets skipped by the asset filter aren't removed from GP Joule
+	_, allClustersByProject, err := mapClustersToProjects(config, clusters)
+	if err != nil {
+		log.Error("eliona", "Error mapping clusters to projects for config %d: %v", *config.Id, err)
+		return nil, err
+	}
+
 	// Create asset tree for each project id
-	for _, projectId := range *config.ProjectIDs {
+	for _, projectId := range projectIds {
 
 		log.Debug("eliona", "Start creating assets for config %d", *config.Id)
 
 		// Create asset tree
+		projectClusters := clustersByProject[projectId]
 		root := model.Root{
 			Config:   config,
-			Clusters: clusters,
+			Clusters: projectClusters,
+		}
+
+		// migrate root and archive to GAIs scoped to the configuration
+		err = migrateScopedAssets(config, projectId, &root)
+		if err != nil {
+			log.Error("eliona", "Error migrating root asset for config %d: %v", *config.Id, err)
+			return nil, err
 		}
 
 		// create assets
 		count, err := asset.CreateAssetsAndUpsertData(&root, projectId, nil, nil)
 		if err != nil {
 			log.Error("eliona", "ErrorNotification creating assets for config %d: %v", *config.Id, err)
-			return err
+			return nil, err
 		}
 
 		log.Debug("eliona", "%d assets created for config %d", count, *config.Id)
 
+		// record the names of the assets and rename them if requested
+		err = renameAssets(config, projectId, &root)
+		if err != nil {
+			log.Error("eliona", "Error renaming assets for config %d: %v", *config.Id, err)
+			return nil, err
+		}
+
+		// move assets whose parent changed
+		parents := make(map[string]string)
+		collectParents(&root, "", parents)
+		err = reparentAssets(config, projectId, parents)
+		if err != nil {
+			log.Error("eliona", "Error moving assets for config %d: %v", *config.Id, err)
+			return nil, err
+		}
+
+		// handle assets disappeared from GP Joule, unless GP Joule returned no data at all
+		if len(clusters) > 0 {
+			present := make(map[string]string)
+			collectParents(&model.Root{Config: config, Clusters: allClustersByProject[projectId]}, "", present)
+			err = handleRemovedAssets(config, projectId, &root, parents, present)
+			if err != nil {
+				log.Error("eliona", "Error handling removed assets for config %d: %v", *config.Id, err)
+				return nil, err
+			}
+		}
+
+		// update locations
+		err = updateLocations(config, projectId, projectClusters)
+		if err != nil {
+			log.Error("eliona", "Error updating asset locations for config %d: %v", *config.Id, err)
+			return nil, err
+		}
+
+		// send offline state
+		err = sendOfflineStates(config, projectId, projectClusters)
+		if err != nil {
+			log.Error("eliona", "Error sending offline states for config %d: %v", *config.Id, err)
+			return nil, err
+		}
+
 		// send notification
 		if count > 0 {
 			err = eliona.NotifyUser(config.UserId, projectId, &api.Translation{
@@ -163,199 +340,845 @@ func collectResources(config *apiserver.Configuration) error {
 		}
 	}
 
+	// store connector indices of the assets created
+	err = storeConnectorIndices(config, assignedConnectors)
+	if err != nil {
+		log.Error("eliona", "Error storing connector indices for config %d: %v", *config.Id, err)
+		return nil, err
+	}
+
 	// init assets
 	log.Debug("eliona", "Start init assets for config %d", *config.Id)
 
 	err = eliona.InitAssets(config)
 	if err != nil {
 		log.Error("eliona", "ErrorNotification creating assets: %v", err)
-		return err
+		return nil, err
 	}
 
 	log.Debug("eliona", "Finished init assets for config %d", *config.Id)
 
+	return filteredClusters, nil
+}
+
+// locatedNode is an asset with geo-coordinates.
+type locatedNode interface {
+	GetGAI() string
+	GetLocation() (float64, float64, bool)
+}
+
+// updateLocations sets the geo-coordinates of the clusters and charge points in Eliona. Only assets with
+// a changed location are updated.
+func updateLocations(config *apiserver.Configuration, projectId string, clusters []*model.Cluster) error {
+	var nodes []locatedNode
+	for _, cluster := range clusters {
+		nodes = append(nodes, cluster)
+		for _, chargePoint := range cluster.ChargePoints {
+			nodes = append(nodes, chargePoint)
+		}
+	}
+
+	for _, node := range nodes {
+		latitude, longitude, ok := node.GetLocation()
+		if !ok {
+			continue
+		}
+		dbAsset, err := conf.GetAsset(context.Background(), config, projectId, node.GetGAI())
+		if err != nil {
+			return err
+		}
+		if dbAsset == nil || !locationChanged(dbAsset, latitude, longitude) {
+			continue
+		}
+
+		// check if asset still exists in Eliona
+		exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+
+		err = eliona.UpdateAssetLocation(dbAsset.AssetID.Int32, latitude, longitude)
+		if err != nil {
+			return err
+		}
+
+		// remember location
+		dbAsset.Latitude = null.Float64From(latitude)
+		dbAsset.Longitude = null.Float64From(longitude)
+		_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.Latitude, appdb.AssetColumns.Longitude))
+		if err != nil {
+			return err
+		}
+		log.Debug("eliona", "Updated location of asset %d", dbAsset.AssetID.Int32)
+	}
+	return nil
+}
+
+// locationChanged checks if the location differs from the one last sent to Eliona.
+func locationChanged(dbAsset *appdb.Asset, latitude float64, longitude float64) bool {
+	return !dbAsset.Latitude.Valid || !dbAsset.Longitude.Valid || dbAsset.Latitude.Float64 != latitude || dbAsset.Longitude.Float64 != longitude
+}
+
+// sendOfflineStates tracks when the charge points were last seen online and sends if they are offline
+// longer than the configured threshold.
+func sendOfflineStates(config *apiserver.Configuration, projectId string, clusters []*model.Cluster) error {
+	now := time.Now()
+	for _, cluster := range clusters {
+		for _, chargePoint := range cluster.ChargePoints {
+			dbAsset, err := conf.GetAsset(context.Background(), config, projectId, chargePoint.GetGAI())
+			if err != nil {
+				return err
+			}
+			if dbAsset == nil {
+				continue
+			}
+
+			// remember when the charge point was last seen online
+			online := chargePoint.IsOnline()
+			if online {
+				dbAsset.LastSeen = now
+				_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.LastSeen))
+				if err != nil {
+					return err
+				}
+			}
+
+			offline := 0
+			if isOffline(online, dbAsset.LastSeen, now, time.Duration(*config.OfflineThreshold)*time.Second) {
+				offline = 1
+			}
+			err = asset.UpsertData(api.Data{
+				AssetId:   dbAsset.AssetID.Int32,
+				Subtype:   "status",
+				Timestamp: *api.NewNullableTime(&now),
+				Data: map[string]any{
+					"offline": offline,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
-func sendSessions(config *apiserver.Configuration) error {
+// isOffline checks if a charge point last seen online at lastSeen is offline longer than the threshold.
+func isOffline(online bool, lastSeen time.Time, now time.Time, threshold time.Duration) bool {
+	return !online && now.Sub(lastSeen) > threshold
+}
+
+func sendSessions(config *apiserver.Configuration, client gp_joule.Client, store store, clusters []*model.Cluster) error {
 
-	dbConnectorAssets, err := conf.GetConnectors(context.Background(), config)
+	dbConnectorAssets, err := store.GetConnectors(config)
 	if err != nil {
 		log.Error("eliona", "Error getting connectors: %v", err)
 		return err
 	}
+	dbConnectorAssets = includedConnectors(dbConnectorAssets, clusters)
 
 	log.Debug("eliona", "Start sending sessions for config %d", *config.Id)
+	chargePointIds, dbConnectorAssetsByChargePoint := groupByChargePoint(dbConnectorAssets)
+	for _, chargePointId := range chargePointIds {
+		count, err := sendChargePointSessions(config, client, store, chargePointId, dbConnectorAssetsByChargePoint[chargePointId])
+		if err != nil {
+			return err
+		}
+		log.Debug("eliona", "Finished sending %d sessions for charge point %s for config %d", count, chargePointId, *config.Id)
+	}
+
+	log.Debug("eliona", "Finished sending sessions for config %d", *config.Id)
+
+	return nil
+}
+
+// sessionTarget holds the connector of a project, its session log and its charge point a completed session is
+// sent to.
+type sessionTarget struct {
+	dbConnectorAsset   *appdb.Asset
+	dbSessionsLogAsset *appdb.Asset
+
+	// dbChargePointAsset aggregates the sessions of all connectors of the charge point in the project. It is nil if
+	// the charge point doesn't exist in Eliona.
+	dbChargePointAsset *appdb.Asset
+
+	// overlapFrom is the start of the overlap window. Sessions ending in the window before the latest session sent
+	// are sent if not recorded as sent. It is zero if no session was recorded for the connector yet.
+	overlapFrom time.Time
+}
+
+// targetKey identifies the asset of a resource in a project. A resource mapped to several projects has an asset
+// in each of them.
+type targetKey struct {
+	projectId  string
+	providerId string
+}
+
+// sendChargePointSessions reads the completed sessions of the charge point once and sends them to the
+// session logs of the corresponding connectors in all projects.
+func sendChargePointSessions(config *apiserver.Configuration, client gp_joule.Client, store store, chargePointId string, dbConnectorAssets appdb.AssetSlice) (int, error) {
+	var count = 0
+
+	targets := make(map[targetKey]sessionTarget)
+	var projectIds []string
+	dbChargePointAssets := make(map[string]*appdb.Asset)
+	overlap := time.Duration(common.Val(config.SessionOverlap)) * time.Second
+	var from time.Time
 	for _, dbConnectorAsset := range dbConnectorAssets {
-		var count = 0
 
 		// check if asset still exists in Eliona
-		exists, err := asset.ExistAsset(dbConnectorAsset.AssetID.Int32)
+		exists, err := store.ExistAsset(dbConnectorAsset.AssetID.Int32)
 		if err != nil {
 			log.Error("eliona", "Error checking asset exists: %v", err)
-			return err
+			return count, err
+		}
+		if !exists {
+			continue
 		}
 
-		if exists {
+		// Get sessions asset for this
+		dbSessionsLogAsset, err := store.GetSessionsLog(config, dbConnectorAsset.ProjectID, dbConnectorAsset.ProviderID)
+		if err != nil {
+			log.Error("eliona", "Error getting sessions log : %v", err)
+			return count, err
+		}
+		if dbSessionsLogAsset == nil {
+			continue
+		}
 
-			// get all sessions
-			completedSessions, err := gp_joule.GetCompletedSessions(config, dbConnectorAsset)
+		// the charge point aggregates the sessions of all its connectors in the project
+		dbChargePointAsset, ok := dbChargePointAssets[dbConnectorAsset.ProjectID]
+		if !ok {
+			dbChargePointAsset, err = existingChargePoint(config, store, dbConnectorAsset.ProjectID, chargePointId)
 			if err != nil {
-				log.Error("api", "Error collecting completed sessions: %v", err)
-				return err
+				return count, err
 			}
+			dbChargePointAssets[dbConnectorAsset.ProjectID] = dbChargePointAsset
+			projectIds = append(projectIds, dbConnectorAsset.ProjectID)
+		}
 
-			// Get sessions asset for this
-			dbSessionsLogAsset, err := conf.GetSessionsLog(context.Background(), dbConnectorAsset.ProviderID)
-			if err != nil {
-				log.Error("eliona", "Error getting sessions log : %v", err)
-				return err
+		// sessions sent before sessions were recorded must not be sent again
+		firstSessionEnd, err := store.GetFirstSessionEnd(config, dbConnectorAsset.ProviderID)
+		if err != nil {
+			log.Error("eliona", "Error getting first session recorded: %v", err)
+			return count, err
+		}
+
+		target := sessionTarget{
+			dbConnectorAsset:   dbConnectorAsset,
+			dbSessionsLogAsset: dbSessionsLogAsset,
+			dbChargePointAsset: dbChargePointAsset,
+		}
+		readFrom := latestRead(dbConnectorAsset.LatestSessionTS, dbConnectorAsset.SessionsReadUntil).Add(-overlap)
+		if firstSessionEnd != nil {
+			target.overlapFrom = readFrom
+			if firstSessionEnd.After(readFrom) {
+				target.overlapFrom = *firstSessionEnd
 			}
+		}
+		targets[targetKey{projectId: dbConnectorAsset.ProjectID, providerId: dbConnectorAsset.ProviderID}] = target
+
+		// read from the connector with the oldest session
+		if len(targets) == 1 || readFrom.Before(from) {
+			from = readFrom
+		}
+	}
+	if len(targets) == 0 {
+		return count, nil
+	}
+	var dbTargetAssets appdb.AssetSlice
+	for _, target := range targets {
+		dbTargetAssets = append(dbTargetAssets, target.dbConnectorAsset)
+	}
 
-			if dbSessionsLogAsset != nil {
-
-				// send sessions to Eliona
-				for _, completedSession := range completedSessions {
-
-					// send new session as data to Eliona
-					err = asset.UpsertData(api.Data{
-						AssetId:   dbSessionsLogAsset.AssetID.Int32,
-						Subtype:   "input",
-						Timestamp: *api.NewNullableTime(completedSession.SessionEnd),
-						Data: map[string]any{
-							"count":    1, // Helper attribute to calculate number of charging sessions in Eliona.
-							"energy":   int(math.Max(float64(completedSession.MeterTotal), 0)),
-							"duration": completedSession.Duration,
-						},
-					})
-					if err != nil {
-						log.Error("api", "Error upserting data in Eliona: %v", err)
-						return err
-					}
-
-					// remember latest timestamp
-					dbConnectorAsset.LatestSessionTS = *completedSession.SessionEnd
-					_, err = dbConnectorAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.LatestSessionTS))
-					if err != nil {
-						log.Error("api", "ErrorNotification updating asset latest session timestamp: %v", err)
-						return err
-					}
-
-					count++
+	// get all sessions window by window and send them to Eliona
+	err := client.GetCompletedSessions(chargePointId, from, func(completedSessions []*model.ChargingSession, readUntil time.Time) error {
+		for _, completedSession := range completedSessions {
+
+			// send new session to the connector in each project, unless it was sent before
+			delivered := false
+			for _, target := range newSessionTargets(targets, projectIds, completedSession) {
+				sent, err := deliverSession(config, store, target, completedSession)
+				if err != nil {
+					log.Error("api", "Error delivering session %s: %v", completedSession.Id, err)
+					return err
 				}
+				delivered = delivered || sent
+			}
+			if !delivered {
+				continue
 			}
+
+			count++
+		}
+
+		// continue after the window on the next run, even if there were no sessions
+		if err := store.SetSessionsReadUntil(dbTargetAssets, readUntil); err != nil {
+			log.Error("eliona", "Error storing sessions read: %v", err)
+			return err
 		}
+		return nil
+	})
+	if err != nil {
+		log.Error("api", "Error collecting completed sessions: %v", err)
+		return count, err
+	}
+
+	return count, nil
+}
 
-		log.Debug("eliona", "Finished sending %d sessions for asset %d for config %d", count, dbConnectorAsset.AssetID.Int32, *config.Id)
+// latestRead returns the time to read again from for an asset. It is the latest data sent to the asset, or the time
+// the data was read until if later, so assets without new data don't read all data again on every run.
+func latestRead(latestData time.Time, readUntil time.Time) time.Time {
+	if readUntil.After(latestData) {
+		return readUntil
 	}
+	return latestData
+}
 
-	log.Debug("eliona", "Finished sending sessions for config %d", *config.Id)
+// existingChargePoint returns the charge point in the project, or nil if it doesn't exist in Eliona.
+func existingChargePoint(config *apiserver.Configuration, store store, projectId string, chargePointId string) (*appdb.Asset, error) {
+	dbChargePointAsset, err := store.GetChargePoint(config, projectId, chargePointId)
+	if err != nil {
+		log.Error("eliona", "Error getting charge point: %v", err)
+		return nil, err
+	}
+	if dbChargePointAsset == nil {
+		return nil, nil
+	}
+	exists, err := store.ExistAsset(dbChargePointAsset.AssetID.Int32)
+	if err != nil {
+		log.Error("eliona", "Error checking asset exists: %v", err)
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return dbChargePointAsset, nil
+}
 
-	return nil
+// deliverSession sends the completed session to the session log and the charge point of the target. The
+// session is recorded before sending, and each data sent is recorded as delivered right after. So a session failing
+// partially only sends the data missing when read again. The session cursor of the connector is advanced once all
+// data is delivered. It returns false if all data was delivered before.
+func deliverSession(config *apiserver.Configuration, store store, target sessionTarget, completedSession *model.ChargingSession) (bool, error) {
+	dbSession, err := store.GetSession(config, completedSession.Id)
+	if err != nil {
+		log.Error("eliona", "Error getting session: %v", err)
+		return false, err
+	}
+	if dbSession == nil {
+		dbSession = &appdb.Session{
+			ConfigurationID: *config.Id,
+			SessionID:       completedSession.Id,
+			ConnectorID:     target.dbConnectorAsset.ProviderID,
+			SessionEnd:      *completedSession.SessionEnd,
+		}
+		if err := store.StoreSession(dbSession); err != nil {
+			log.Error("eliona", "Error recording session: %v", err)
+			return false, err
+		}
+	}
+
+	delivered := false
+	for _, data := range sessionDeliveries(target, completedSession) {
+		key := deliveryKey(data)
+		if slices.Contains(dbSession.Delivered, key) {
+			continue
+		}
+		if err := store.UpsertData(data); err != nil {
+			log.Error("api", "Error upserting data in Eliona: %v", err)
+			return delivered, err
+		}
+		delivered = true
+		dbSession.Delivered = append(dbSession.Delivered, key)
+		if err := store.StoreSession(dbSession); err != nil {
+			log.Error("eliona", "Error recording session delivered: %v", err)
+			return delivered, err
+		}
+	}
+	if delivered && completedSession.Currency != "" && completedSession.Currency != currency {
+		log.Warn("api", "Session %s has costs in %s instead of %s, the costs are skipped", completedSession.Id, completedSession.Currency, currency)
+	}
+
+	if conf.AfterSessionCursor(target.dbConnectorAsset, *completedSession.SessionEnd, completedSession.Id) {
+		target.dbConnectorAsset.LatestSessionTS = *completedSession.SessionEnd
+		target.dbConnectorAsset.LatestSessionID = completedSession.Id
+		if err := store.SetSessionCursor(target.dbConnectorAsset); err != nil {
+			log.Error("eliona", "Error storing latest session: %v", err)
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// sessionDeliveries returns the data of the completed session sent to the session log and the charge point of the
+// target.
+func sessionDeliveries(target sessionTarget, completedSession *model.ChargingSession) []api.Data {
+	deliveries := []api.Data{
+		{
+			AssetId:   target.dbSessionsLogAsset.AssetID.Int32,
+			Subtype:   "input",
+			Timestamp: *api.NewNullableTime(completedSession.SessionEnd),
+			Data:      sessionLogData(completedSession),
+		},
+		{
+			AssetId:   target.dbSessionsLogAsset.AssetID.Int32,
+			Subtype:   "info",
+			Timestamp: *api.NewNullableTime(completedSession.SessionEnd),
+			Data: map[string]any{
+				"currency": completedSession.Currency,
+			},
+		},
+	}
+
+	// send session to the charge point as well
+	if target.dbChargePointAsset != nil {
+		deliveries = append(deliveries, api.Data{
+			AssetId:   target.dbChargePointAsset.AssetID.Int32,
+			Subtype:   "input",
+			Timestamp: *api.NewNullableTime(completedSession.SessionEnd),
+			Data:      sessionData(completedSession),
+		})
+	}
+	return deliveries
+}
+
+// deliveryKey identifies the data of a session delivered to an asset in the session record.
+func deliveryKey(data api.Data) string {
+	return fmt.Sprintf("%d/%s", data.AssetId, data.Subtype)
+}
+
+// newSessionTargets returns the targets of the completed session in the projects given. Targets are skipped if
+// the session is ordered before the latest session sent to them and outside the overlap window. Sessions in the
+// overlap window are returned and skipped on delivery if they were sent before.
+func newSessionTargets(targets map[targetKey]sessionTarget, projectIds []string, completedSession *model.ChargingSession) []sessionTarget {
+	var sessionTargets []sessionTarget
+	for _, projectId := range projectIds {
+		target, ok := targets[targetKey{projectId: projectId, providerId: completedSession.ConnectorId}]
+		if !ok {
+			continue
+		}
+		if conf.AfterSessionCursor(target.dbConnectorAsset, *completedSession.SessionEnd, completedSession.Id) ||
+			!target.overlapFrom.IsZero() && !completedSession.SessionEnd.Before(target.overlapFrom) {
+			sessionTargets = append(sessionTargets, target)
+		}
+	}
+	return sessionTargets
+}
+
+// currency is the unit of the cost attributes defined in the asset types.
+const currency = "EUR"
+
+// sessionData returns the data of a completed session summed up by the session log and the charge point. Costs in
+// another currency than the cost attributes are skipped, so they aren't summed up with costs in that currency.
+func sessionData(completedSession *model.ChargingSession) map[string]any {
+	data := map[string]any{
+		"energy":   int(math.Max(float64(completedSession.MeterTotal), 0)),
+		"duration": completedSession.Duration,
+	}
+	if completedSession.Currency == "" || completedSession.Currency == currency {
+		data["costs_net"] = completedSession.CostsNet
+		data["tax_amount"] = completedSession.TaxAmount
+		data["costs"] = completedSession.Costs
+	}
+	return data
 }
 
-func sendErrors(config *apiserver.Configuration) error {
+// sessionLogData returns the data of a completed session sent to the session log. The state of charge
+// is only sent if the vehicle shared it.
+func sessionLogData(completedSession *model.ChargingSession) map[string]any {
+	data := sessionData(completedSession)
+	data["count"] = 1 // Helper attribute to calculate number of charging sessions in Eliona.
+	if completedSession.InitialStateOfCharge.Valid {
+		data["state_of_charge_start"] = completedSession.InitialStateOfCharge.Percent
+	}
+	if completedSession.LastStateOfCharge.Valid {
+		data["state_of_charge_end"] = completedSession.LastStateOfCharge.Percent
+	}
+	return data
+}
+
+func sendErrors(config *apiserver.Configuration, client gp_joule.Client, store store, clusters []*model.Cluster) error {
+
+	dbChargePointAssets, err := store.GetChargePoints(config)
+	if err != nil {
+		log.Error("eliona", "Error getting charge points: %v", err)
+		return err
+	}
+	dbChargePointAssets = includedChargePoints(dbChargePointAssets, clusters)
 
-	dbConnectorAssets, err := conf.GetConnectors(context.Background(), config)
+	dbConnectorAssets, err := store.GetConnectors(config)
 	if err != nil {
 		log.Error("eliona", "Error getting connectors: %v", err)
 		return err
 	}
+	dbConnectorAssets = includedConnectors(dbConnectorAssets, clusters)
+
+	// errors are read per charge point, including charge points without connectors, and per charge point of
+	// connectors whose charge point has no asset
+	var chargePointIds []string
+	dbChargePointAssetsById := make(map[string]appdb.AssetSlice)
+	for _, dbChargePointAsset := range dbChargePointAssets {
+		if _, ok := dbChargePointAssetsById[dbChargePointAsset.ProviderID]; !ok {
+			chargePointIds = append(chargePointIds, dbChargePointAsset.ProviderID)
+		}
+		dbChargePointAssetsById[dbChargePointAsset.ProviderID] = append(dbChargePointAssetsById[dbChargePointAsset.ProviderID], dbChargePointAsset)
+	}
+	connectorChargePointIds, dbConnectorAssetsByChargePoint := groupByChargePoint(dbConnectorAssets)
+	for _, chargePointId := range connectorChargePointIds {
+		if _, ok := dbChargePointAssetsById[chargePointId]; !ok {
+			chargePointIds = append(chargePointIds, chargePointId)
+		}
+	}
 
 	log.Debug("eliona", "Start sending errors for config %d", *config.Id)
-	for _, dbConnectorAsset := range dbConnectorAssets {
-		var openCount = 0
-		var resolvedCount = 0
+	catalogue := model.NewErrorCatalogue(config)
+	for _, chargePointId := range chargePointIds {
+		if err := sendChargePointErrors(config, client, store, catalogue, chargePointId, dbChargePointAssetsById[chargePointId], dbConnectorAssetsByChargePoint[chargePointId]); err != nil {
+			return err
+		}
+	}
+
+	log.Debug("eliona", "Finished sending errors for config %d", *config.Id)
+
+	return nil
+}
+
+// sendChargePointErrors reads the error notifications of the charge point once and sends them to the
+// corresponding connectors in all projects. Errors without connector are sent to the charge point itself. The errors
+// are tracked by their ID, so the error attribute always shows the number of errors open on the asset.
+func sendChargePointErrors(config *apiserver.Configuration, client gp_joule.Client, store store, catalogue *model.ErrorCatalogue, chargePointId string, dbChargePointAssets appdb.AssetSlice, dbConnectorAssets appdb.AssetSlice) error {
+
+	// collect all assets the errors can be sent to in all projects
+	var dbAssets appdb.AssetSlice
+	dbAssets = append(dbAssets, dbChargePointAssets...)
+	dbAssets = append(dbAssets, dbConnectorAssets...)
+	var projectIds []string
+	for _, dbAsset := range dbAssets {
+		if !slices.Contains(projectIds, dbAsset.ProjectID) {
+			projectIds = append(projectIds, dbAsset.ProjectID)
+		}
+	}
+
+	targets := make(map[targetKey]*appdb.Asset)
+	var providerIds []string
+	var from time.Time
+	for _, dbAsset := range dbAssets {
 
 		// check if asset still exists in Eliona
-		exists, err := asset.ExistAsset(dbConnectorAsset.AssetID.Int32)
+		exists, err := store.ExistAsset(dbAsset.AssetID.Int32)
 		if err != nil {
 			log.Error("eliona", "Error checking asset exists: %v", err)
 			return err
 		}
+		if !exists {
+			continue
+		}
 
-		if exists {
+		// errors without connector belong to the charge point
+		if dbAsset.AssetType.String == "gp_joule_charge_point" {
+			targets[targetKey{projectId: dbAsset.ProjectID}] = dbAsset
+		} else {
+			targets[targetKey{projectId: dbAsset.ProjectID, providerId: dbAsset.ProviderID}] = dbAsset
+		}
+		providerIds = append(providerIds, dbAsset.ProviderID)
 
-			// get all open errors
-			errorNotifications, err := gp_joule.GetErrorNotifications(config, dbConnectorAsset)
-			if err != nil {
-				log.Error("api", "Error collecting error notifications: %v", err)
-				return err
+		// read from the asset read the least
+		readFrom := latestRead(dbAsset.LatestErrorTS, dbAsset.ErrorsReadUntil)
+		if len(targets) == 1 || readFrom.Before(from) {
+			from = readFrom
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	// read again from the oldest error still open, so its resolution isn't missed
+	openErrors, err := store.GetOpenErrors(config, providerIds)
+	if err != nil {
+		log.Error("eliona", "Error getting open errors: %v", err)
+		return err
+	}
+	if len(openErrors) > 0 && openErrors[0].OccurredAt.Before(from) {
+		from = openErrors[0].OccurredAt
+	}
+
+	// send and remember the error notifications window by window, so a failure doesn't lose the windows read before
+	var changes int
+	err = client.GetErrorNotifications(chargePointId, from, func(errorNotifications []*model.ErrorNotification, readUntil time.Time) error {
+		count, err := sendErrorWindow(config, store, catalogue, targets, projectIds, providerIds, dbAssets, errorNotifications, readUntil)
+		changes += count
+		return err
+	})
+	if err != nil {
+		log.Error("api", "Error collecting error notifications: %v", err)
+		return err
+	}
+
+	log.Debug("eliona", "Finished sending %d error changes for charge point %s for config %d", changes, chargePointId, *config.Id)
+
+	return nil
+}
+
+// sendErrorWindow sends the changes of the errors notified within a window read and stores the errors together with
+// the cursors of the assets. It returns the number of changes sent.
+func sendErrorWindow(config *apiserver.Configuration, store store, catalogue *model.ErrorCatalogue, targets map[targetKey]*appdb.Asset, projectIds []string, providerIds []string, dbAssets appdb.AssetSlice, errorNotifications []*model.ErrorNotification, readUntil time.Time) (int, error) {
+
+	// the errors open before include the errors stored for the windows before
+	openErrors, err := store.GetOpenErrors(config, providerIds)
+	if err != nil {
+		log.Error("eliona", "Error getting open errors: %v", err)
+		return 0, err
+	}
+
+	// compare with the errors recorded before
+	var errorIds []string
+	for _, errorNotification := range errorNotifications {
+		errorIds = append(errorIds, errorNotification.Id)
+	}
+	recordedErrors := make(map[string]*appdb.Error)
+	if len(errorIds) > 0 {
+		recordedErrors, err = store.GetErrors(config, errorIds)
+		if err != nil {
+			log.Error("eliona", "Error getting recorded errors: %v", err)
+			return 0, err
+		}
+	}
+	dbErrors, events := errorEvents(config, targets, projectIds, errorNotifications, recordedErrors)
+
+	// send the number of open errors after each change to Eliona
+	for _, data := range errorData(catalogue, targets, openErrors, events) {
+		if err := store.UpsertData(data); err != nil {
+			log.Error("api", "Error upserting data in Eliona: %v", err)
+			return 0, err
+		}
+	}
+
+	// remember the errors, the latest error per asset and up to when the errors were read, even if there were none
+	cursors := make(map[*appdb.Asset]bool)
+	for _, errorNotification := range errorNotifications {
+		for _, target := range errorTargets(targets, projectIds, errorNotification) {
+			if errorNotification.OccurredAt != nil && errorNotification.OccurredAt.After(target.LatestErrorTS) {
+				target.LatestErrorTS = *errorNotification.OccurredAt
+				cursors[target] = true
 			}
+		}
+	}
+	for _, target := range targets {
+		if readUntil.After(target.ErrorsReadUntil) {
+			target.ErrorsReadUntil = readUntil
+			cursors[target] = true
+		}
+	}
+	var dbCursorAssets appdb.AssetSlice
+	for _, dbAsset := range dbAssets {
+		if cursors[dbAsset] {
+			dbCursorAssets = append(dbCursorAssets, dbAsset)
+		}
+	}
+	if err := store.StoreErrors(dbErrors, dbCursorAssets); err != nil {
+		log.Error("eliona", "Error storing errors: %v", err)
+		return 0, err
+	}
+	return len(events), nil
+}
 
-			// send all new resolved errors
-			for _, errorNotification := range errorNotifications {
-
-				// Close all errors that are resolved until an open error is found
-				if errorNotification.ResolvedAt != nil {
-					resolvedCount++
-
-					// reset resoled error as data to Eliona
-					err = asset.UpsertData(api.Data{
-						AssetId:   dbConnectorAsset.AssetID.Int32,
-						Subtype:   "status",
-						Timestamp: *api.NewNullableTime(errorNotification.ResolvedAt),
-						Data: map[string]any{
-							"error":         0,
-							"error_message": "-",
-						},
-					})
-					if err != nil {
-						log.Error("api", "Error upserting data in Eliona: %v", err)
-						return err
-					}
-					// remember latest timestamp
-					dbConnectorAsset.LatestErrorTS = *errorNotification.OccurredAt
-					_, err = dbConnectorAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.LatestErrorTS))
-					if err != nil {
-						log.Error("api", "ErrorNotification updating asset latest session timestamp: %v", err)
-						return err
-					}
+// errorEvent is an error opened or resolved on the asset at the time given.
+type errorEvent struct {
+	time     time.Time
+	target   *appdb.Asset
+	dbError  *appdb.Error
+	resolved bool
+}
 
-				}
+// errorEvents compares the error notifications with the errors recorded before. It returns the errors new or
+// resolved since, and the events to send for them to the assets in all projects sorted by their time. Notifications
+// of unknown assets are skipped.
+func errorEvents(config *apiserver.Configuration, targets map[targetKey]*appdb.Asset, projectIds []string, errorNotifications []*model.ErrorNotification, recordedErrors map[string]*appdb.Error) (appdb.ErrorSlice, []errorEvent) {
+	var dbErrors appdb.ErrorSlice
+	var events []errorEvent
+	for _, errorNotification := range errorNotifications {
+		notificationTargets := errorTargets(targets, projectIds, errorNotification)
+		if len(notificationTargets) == 0 || errorNotification.OccurredAt == nil {
+			continue
+		}
+
+		dbError := recordedErrors[errorNotification.Id]
+		opened := dbError == nil
+		if opened {
+			dbError = &appdb.Error{
+				ConfigurationID: *config.Id,
+				ErrorID:         errorNotification.Id,
+				ChargePointID:   errorNotification.ChargePointId,
+				ProviderID:      notificationTargets[0].ProviderID,
+				ErrorCode:       errorNotification.ErrorCode,
+				ErrorInfo:       errorNotification.ErrorInfo,
+				VendorCode:      errorNotification.VendorCode,
+				OccurredAt:      *errorNotification.OccurredAt,
 			}
+		} else if dbError.ResolvedAt.Valid || errorNotification.ResolvedAt == nil {
+			continue // nothing changed
+		}
+		dbErrors = append(dbErrors, dbError)
 
-			// get all open errors
-			errorNotifications, err = gp_joule.GetErrorNotifications(config, dbConnectorAsset)
-			if err != nil {
-				log.Error("api", "Error collecting error notifications: %v", err)
-				return err
+		if errorNotification.ResolvedAt != nil {
+			dbError.ResolvedAt = null.TimeFrom(*errorNotification.ResolvedAt)
+		}
+		for _, target := range notificationTargets {
+			if opened {
+				events = append(events, errorEvent{time: dbError.OccurredAt, target: target, dbError: dbError})
+			}
+			if errorNotification.ResolvedAt != nil {
+				events = append(events, errorEvent{time: *errorNotification.ResolvedAt, target: target, dbError: dbError, resolved: true})
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time.Before(events[j].time)
+	})
+	return dbErrors, events
+}
+
+// errorData returns the error attributes after each event, starting from the errors open before on the targets. The
+// error attribute is the number of errors open on the asset, the error message shows the latest error open. The number
+// of errors open is also given per severity, so each severity raises an alarm of its priority.
+func errorData(catalogue *model.ErrorCatalogue, targets map[targetKey]*appdb.Asset, openErrors appdb.ErrorSlice, events []errorEvent) []api.Data {
+	open := make(map[*appdb.Asset]map[string]*appdb.Error)
+	add := func(target *appdb.Asset, dbError *appdb.Error) {
+		if open[target] == nil {
+			open[target] = make(map[string]*appdb.Error)
+		}
+		open[target][dbError.ErrorID] = dbError
+	}
+	for _, target := range targets {
+		for _, dbError := range openErrors {
+			if dbError.ProviderID == target.ProviderID {
+				add(target, dbError)
 			}
+		}
+	}
 
-			// send all open errors
-			for _, errorNotification := range errorNotifications {
-
-				// Send open errors to Eliona
-				if errorNotification.ResolvedAt == nil {
-					openCount++
-
-					// send new error as data to Eliona
-					err = asset.UpsertData(api.Data{
-						AssetId:   dbConnectorAsset.AssetID.Int32,
-						Subtype:   "status",
-						Timestamp: *api.NewNullableTime(errorNotification.OccurredAt),
-						Data: map[string]any{
-							"error":         openCount,
-							"error_message": fmt.Sprintf("%s: %s (%s)", errorNotification.ErrorCode, errorNotification.ErrorInfo, errorNotification.Id),
-						},
-					})
-					if err != nil {
-						log.Error("api", "Error upserting data in Eliona: %v", err)
-						return err
-					}
+	var data []api.Data
+	for _, event := range events {
+		if event.resolved {
+			delete(open[event.target], event.dbError.ErrorID)
+		} else {
+			add(event.target, event.dbError)
+		}
 
-				}
+		severities := make(map[string]int)
+		var latest *appdb.Error
+		for _, dbError := range open[event.target] {
+			severities[catalogue.Severity(dbError.ErrorCode, dbError.VendorCode)]++
+			if latest == nil || dbError.OccurredAt.After(latest.OccurredAt) ||
+				dbError.OccurredAt.Equal(latest.OccurredAt) && dbError.ErrorID > latest.ErrorID {
+				latest = dbError
 			}
+		}
 
+		attributes := map[string]any{
+			"error":         len(open[event.target]),
+			"error_message": "-",
+		}
+		if latest != nil {
+			attributes["error_message"] = errorMessage(catalogue, latest)
+		}
+		for _, severity := range conf.ErrorSeverities {
+			attributes["error_"+severity] = severities[severity]
 		}
 
-		log.Debug("eliona", "Finished opening %d new errors for asset %d for config %d", openCount, dbConnectorAsset.AssetID.Int32, *config.Id)
-		log.Debug("eliona", "Finished closing %d resolved errors for asset %d for config %d", resolvedCount, dbConnectorAsset.AssetID.Int32, *config.Id)
+		data = append(data, api.Data{
+			AssetId:   event.target.AssetID.Int32,
+			Subtype:   "status",
+			Timestamp: *api.NewNullableTime(&event.time),
+			Data:      attributes,
+		})
 	}
+	return data
+}
 
-	log.Debug("eliona", "Finished sending errors for config %d", *config.Id)
+// errorMessage returns the message shown for the error, using the text of the error code catalogue.
+func errorMessage(catalogue *model.ErrorCatalogue, dbError *appdb.Error) string {
+	text := catalogue.Text(dbError.ErrorCode, dbError.VendorCode)
+	if dbError.ErrorInfo == "" {
+		return fmt.Sprintf("%s (%s)", text, dbError.ErrorID)
+	}
+	return fmt.Sprintf("%s: %s (%s)", text, dbError.ErrorInfo, dbError.ErrorID)
+}
 
-	return nil
+// errorTargets returns the assets of the error notification in the projects given. Errors without connector belong
+// to the charge point. Projects without the asset are skipped.
+func errorTargets(targets map[targetKey]*appdb.Asset, projectIds []string, errorNotification *model.ErrorNotification) []*appdb.Asset {
+	providerId := ""
+	if errorNotification.ConnectorId != nil {
+		providerId = *errorNotification.ConnectorId
+	}
+	var notificationTargets []*appdb.Asset
+	for _, projectId := range projectIds {
+		if target, ok := targets[targetKey{projectId: projectId, providerId: providerId}]; ok {
+			notificationTargets = append(notificationTargets, target)
+		}
+	}
+	return notificationTargets
+}
+
+// groupByChargePoint groups the connector assets by their charge point. The charge point ids are
+// returned in order of appearance.
+func groupByChargePoint(dbConnectorAssets appdb.AssetSlice) ([]string, map[string]appdb.AssetSlice) {
+	var chargePointIds []string
+	grouped := make(map[string]appdb.AssetSlice)
+	for _, dbConnectorAsset := range dbConnectorAssets {
+		if _, ok := grouped[dbConnectorAsset.ParentProviderID]; !ok {
+			chargePointIds = append(chargePointIds, dbConnectorAsset.ParentProviderID)
+		}
+		grouped[dbConnectorAsset.ParentProviderID] = append(grouped[dbConnectorAsset.ParentProviderID], dbConnectorAsset)
+	}
+	return chargePointIds, grouped
+}
+
+// includedChargePoints returns the charge point assets whose charge point is part of the clusters, so charge points
+// excluded by the asset filter are skipped.
+func includedChargePoints(dbChargePointAssets appdb.AssetSlice, clusters []*model.Cluster) appdb.AssetSlice {
+	chargePointIds := make(map[string]bool)
+	for _, cluster := range clusters {
+		for _, chargePoint := range cluster.ChargePoints {
+			chargePointIds[chargePoint.ChargePointId] = true
+		}
+	}
+
+	var included appdb.AssetSlice
+	for _, dbChargePointAsset := range dbChargePointAssets {
+		if chargePointIds[dbChargePointAsset.ProviderID] {
+			included = append(included, dbChargePointAsset)
+		}
+	}
+	return included
+}
+
+// includedConnectors returns the connector assets whose connector is part of the clusters, so connectors excluded
+// by the asset filter are skipped.
+func includedConnectors(dbConnectorAssets appdb.AssetSlice, clusters []*model.Cluster) appdb.AssetSlice {
+	connectorIds := make(map[string]bool)
+	for _, cluster := range clusters {
+		for _, chargePoint := range cluster.ChargePoints {
+			for _, connector := range chargePoint.Connectors {
+				connectorIds[connector.ConnectorId] = true
+			}
+		}
+	}
+
+	var included appdb.AssetSlice
+	for _, dbConnectorAsset := range dbConnectorAssets {
+		if connectorIds[dbConnectorAsset.ProviderID] {
+			included = append(included, dbConnectorAsset)
+		}
+	}
+	return included
 }
 
 // listenApi starts the API server and listen for requests
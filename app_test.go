@@ -0,0 +1,526 @@
+package main
+
+import (
+	"fmt"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/gp_joule"
+	"gp-joule/gp_joule/gp_jouletest"
+	"gp-joule/model"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+)
+
+func fakeClient() *gp_joule.FakeClient {
+	clusters, sessions, notifications := gp_jouletest.Fixtures()
+	return &gp_joule.FakeClient{Clusters: clusters, Sessions: sessions, ErrorNotifications: notifications}
+}
+
+func TestGroupByChargePoint(t *testing.T) {
+	chargePointIds, grouped := groupByChargePoint(appdb.AssetSlice{
+		{ProviderID: "con-2-1", ParentProviderID: "cp-2"},
+		{ProviderID: "con-1-1", ParentProviderID: "cp-1"},
+		{ProviderID: "con-1-2", ParentProviderID: "cp-1"},
+	})
+	if len(chargePointIds) != 2 || chargePointIds[0] != "cp-2" || chargePointIds[1] != "cp-1" {
+		t.Errorf("unexpected charge points %v", chargePointIds)
+	}
+	if len(grouped["cp-1"]) != 2 || len(grouped["cp-2"]) != 1 {
+		t.Errorf("unexpected grouping %v", grouped)
+	}
+}
+
+func TestNewSessionTargets(t *testing.T) {
+	targets := map[targetKey]sessionTarget{
+		{projectId: "1", providerId: "con-1-1"}: {dbConnectorAsset: &appdb.Asset{ProjectID: "1", ProviderID: "con-1-1", AssetID: null.Int32From(1), LatestSessionTS: time.Date(2024, 4, 3, 9, 0, 0, 0, time.UTC)}},
+		{projectId: "1", providerId: "con-1-2"}: {dbConnectorAsset: &appdb.Asset{ProjectID: "1", ProviderID: "con-1-2", AssetID: null.Int32From(2)}},
+		{projectId: "2", providerId: "con-1-1"}: {dbConnectorAsset: &appdb.Asset{ProjectID: "2", ProviderID: "con-1-1", AssetID: null.Int32From(3)}},
+	}
+
+	var sent []string
+	err := fakeClient().GetCompletedSessions("cp-1", time.Time{}, func(sessions []*model.ChargingSession, _ time.Time) error {
+		for _, session := range sessions {
+			for _, target := range newSessionTargets(targets, []string{"1", "2"}, session) {
+				sent = append(sent, session.Id+"@"+target.dbConnectorAsset.ProjectID+"/"+target.dbConnectorAsset.ProviderID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// s-3 was already sent to con-1-1 in project 1, but not in project 2
+	if !slices.Equal(sent, []string{"s-1@1/con-1-2", "s-3@2/con-1-1"}) {
+		t.Errorf("unexpected sessions sent %v", sent)
+	}
+}
+
+func TestNewSessionTargetOverlap(t *testing.T) {
+	at := func(hour int) *time.Time {
+		return common.Ptr(time.Date(2024, 4, 1, hour, 0, 0, 0, time.UTC))
+	}
+	dbConnectorAsset := &appdb.Asset{ProviderID: "con-1-1", LatestSessionTS: *at(12), LatestSessionID: "b"}
+	key := targetKey{projectId: "1", providerId: "con-1-1"}
+	targets := map[targetKey]sessionTarget{
+		key: {dbConnectorAsset: dbConnectorAsset, overlapFrom: *at(10)},
+	}
+
+	// overlapping sessions on one connector, some reported late
+	sessions := []*model.ChargingSession{
+		{Id: "early", ConnectorId: "con-1-1", SessionStart: at(7), SessionEnd: at(9)},
+		{Id: "late", ConnectorId: "con-1-1", SessionStart: at(9), SessionEnd: at(11)},
+		{Id: "a", ConnectorId: "con-1-1", SessionStart: at(8), SessionEnd: at(12)},
+		{Id: "c", ConnectorId: "con-1-1", SessionStart: at(10), SessionEnd: at(12)},
+		{Id: "d", ConnectorId: "con-1-1", SessionStart: at(11), SessionEnd: at(13)},
+	}
+	var sent []string
+	for _, session := range sessions {
+		if len(newSessionTargets(targets, []string{"1"}, session)) > 0 {
+			sent = append(sent, session.Id)
+		}
+	}
+
+	// sessions in the overlap window are checked against the sessions recorded on delivery
+	if !slices.Equal(sent, []string{"late", "a", "c", "d"}) {
+		t.Errorf("unexpected sessions %v", sent)
+	}
+
+	// without sessions recorded only sessions after the cursor are sent
+	targets[key] = sessionTarget{dbConnectorAsset: dbConnectorAsset}
+	sent = nil
+	for _, session := range sessions {
+		if len(newSessionTargets(targets, []string{"1"}, session)) > 0 {
+			sent = append(sent, session.Id)
+		}
+	}
+	if !slices.Equal(sent, []string{"c", "d"}) {
+		t.Errorf("unexpected sessions without overlap %v", sent)
+	}
+}
+
+func TestSessionData(t *testing.T) {
+	data := sessionData(&model.ChargingSession{MeterTotal: -5, Duration: 60, CostsNet: 16.81, TaxAmount: 3.19, Costs: 20})
+	if data["energy"] != 0 || data["duration"] != 60 || data["costs_net"] != 16.81 || data["tax_amount"] != 3.19 || data["costs"] != 20.0 {
+		t.Errorf("unexpected data %v", data)
+	}
+	if _, ok := data["count"]; ok {
+		t.Errorf("unexpected count in %v", data)
+	}
+}
+
+func TestSessionDataOtherCurrency(t *testing.T) {
+	data := sessionData(&model.ChargingSession{MeterTotal: 100, Duration: 60, CostsNet: 16.81, TaxAmount: 3.19, Costs: 20, Currency: "CHF"})
+	if data["energy"] != 100 || data["duration"] != 60 {
+		t.Errorf("unexpected data %v", data)
+	}
+	for _, attribute := range []string{"costs_net", "tax_amount", "costs"} {
+		if _, ok := data[attribute]; ok {
+			t.Errorf("unexpected %s in %v", attribute, data)
+		}
+	}
+	if data := sessionData(&model.ChargingSession{Costs: 20, Currency: "EUR"}); data["costs"] != 20.0 {
+		t.Errorf("unexpected costs in %v", data)
+	}
+}
+
+func TestSessionLogData(t *testing.T) {
+	data := sessionLogData(&model.ChargingSession{
+		MeterTotal:        100,
+		LastStateOfCharge: model.StateOfCharge{Percent: 80, Valid: true},
+	})
+	if data["count"] != 1 || data["energy"] != 100 || data["state_of_charge_end"] != 80.0 {
+		t.Errorf("unexpected data %v", data)
+	}
+	if _, ok := data["state_of_charge_start"]; ok {
+		t.Errorf("unexpected state of charge at start in %v", data)
+	}
+}
+
+func TestErrorTargets(t *testing.T) {
+	dbChargePointAsset := &appdb.Asset{ProjectID: "1", ProviderID: "cp-1"}
+	dbConnectorAsset := &appdb.Asset{ProjectID: "1", ProviderID: "con-1-1"}
+	dbConnectorAsset2 := &appdb.Asset{ProjectID: "2", ProviderID: "con-1-1"}
+	targets := map[targetKey]*appdb.Asset{
+		{projectId: "1"}:                        dbChargePointAsset,
+		{projectId: "1", providerId: "con-1-1"}: dbConnectorAsset,
+		{projectId: "2", providerId: "con-1-1"}: dbConnectorAsset2,
+	}
+
+	got := make(map[string][]*appdb.Asset)
+	err := fakeClient().GetErrorNotifications("cp-1", time.Time{}, func(notifications []*model.ErrorNotification, _ time.Time) error {
+		for _, notification := range notifications {
+			got[notification.Id] = errorTargets(targets, []string{"1", "2"}, notification)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got["e-1"], []*appdb.Asset{dbConnectorAsset, dbConnectorAsset2}) ||
+		!slices.Equal(got["e-2"], []*appdb.Asset{dbChargePointAsset}) || len(got["e-3"]) != 0 {
+		t.Errorf("unexpected targets %v", got)
+	}
+}
+
+func TestErrorData(t *testing.T) {
+	at := func(hour, minute int) *time.Time {
+		return common.Ptr(time.Date(2024, 4, 1, hour, minute, 0, 0, time.UTC))
+	}
+	notification := func(id string, connectorId *string, occurredAt *time.Time, resolvedAt *time.Time) *model.ErrorNotification {
+		return &model.ErrorNotification{Id: id, ChargePointId: "cp-1", ConnectorId: connectorId, ErrorCode: "OtherError", OccurredAt: occurredAt, ResolvedAt: resolvedAt}
+	}
+	dbChargePointAsset := &appdb.Asset{ProviderID: "cp-1", AssetID: null.Int32From(10)}
+	dbConnectorAsset := &appdb.Asset{ProviderID: "con-1-1", AssetID: null.Int32From(11)}
+	targets := map[targetKey]*appdb.Asset{{projectId: "1"}: dbChargePointAsset, {projectId: "1", providerId: "con-1-1"}: dbConnectorAsset}
+
+	// an error still open from an earlier run and one resolved before
+	openError := &appdb.Error{ErrorID: "open", ProviderID: "con-1-1", ErrorCode: "OtherError", OccurredAt: *at(6, 0)}
+	recordedErrors := map[string]*appdb.Error{
+		"open": {ErrorID: "open", ProviderID: "con-1-1", ErrorCode: "OtherError", OccurredAt: *at(6, 0)},
+		"done": {ErrorID: "done", ProviderID: "con-1-1", ErrorCode: "OtherError", OccurredAt: *at(5, 0), ResolvedAt: null.TimeFrom(*at(5, 30))},
+	}
+	notifications := []*model.ErrorNotification{
+		notification("done", common.Ptr("con-1-1"), at(5, 0), at(5, 30)),
+		notification("open", common.Ptr("con-1-1"), at(6, 0), at(8, 0)),
+		notification("e-1", common.Ptr("con-1-1"), at(7, 0), at(7, 30)),
+		notification("e-2", nil, at(7, 10), nil),
+		{Id: "e-3", ChargePointId: "cp-1", ConnectorId: common.Ptr("con-1-1"), ErrorCode: "GroundFailure", ErrorInfo: "Ground fault detected", OccurredAt: at(7, 45)},
+		notification("e-4", common.Ptr("con-unknown"), at(7, 50), nil),
+	}
+
+	dbErrors, events := errorEvents(&apiserver.Configuration{Id: common.Ptr[int64](1)}, targets, []string{"1"}, notifications, recordedErrors)
+	var ids []string
+	for _, dbError := range dbErrors {
+		ids = append(ids, dbError.ErrorID)
+	}
+	if !slices.Equal(ids, []string{"open", "e-1", "e-2", "e-3"}) {
+		t.Errorf("unexpected errors stored %v", ids)
+	}
+	if !recordedErrors["open"].ResolvedAt.Valid {
+		t.Errorf("error open before not resolved")
+	}
+
+	var got []string
+	for _, data := range errorData(model.NewErrorCatalogue(nil), targets, appdb.ErrorSlice{openError}, events) {
+		got = append(got, fmt.Sprintf("%d@%s:%v/%v/%v/%v %v", data.AssetId, data.Timestamp.Get().Format("15:04"),
+			data.Data["error"], data.Data["error_high"], data.Data["error_medium"], data.Data["error_low"], data.Data["error_message"]))
+	}
+	expected := []string{
+		"11@07:00:2/0/2/0 Other error (e-1)",
+		"10@07:10:1/0/1/0 Other error (e-2)",
+		"11@07:30:1/0/1/0 Other error (open)",
+		"11@07:45:2/1/1/0 Ground failure: Ground fault detected (e-3)",
+		"11@08:00:1/1/0/0 Ground failure: Ground fault detected (e-3)",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("unexpected error data %v", got)
+	}
+}
+
+func TestLocationChanged(t *testing.T) {
+	dbAsset := &appdb.Asset{}
+	if !locationChanged(dbAsset, 54.1, 9.2) {
+		t.Error("expected change for asset without location")
+	}
+	dbAsset.Latitude = null.Float64From(54.1)
+	dbAsset.Longitude = null.Float64From(9.2)
+	if locationChanged(dbAsset, 54.1, 9.2) {
+		t.Error("expected no change for same location")
+	}
+	if !locationChanged(dbAsset, 54.1, 9.3) {
+		t.Error("expected change for moved asset")
+	}
+}
+
+func TestClusterLocation(t *testing.T) {
+	clusters, _, _ := gp_jouletest.Fixtures()
+	cluster := clusters[0]
+	latitude, longitude, ok := cluster.GetLocation()
+	if !ok {
+		t.Fatal("expected location")
+	}
+	var expectedLatitude, expectedLongitude float64
+	for _, chargePoint := range cluster.ChargePoints {
+		expectedLatitude += chargePoint.Lat / float64(len(cluster.ChargePoints))
+		expectedLongitude += chargePoint.Long / float64(len(cluster.ChargePoints))
+	}
+	if math.Abs(latitude-expectedLatitude) > 1e-9 || math.Abs(longitude-expectedLongitude) > 1e-9 {
+		t.Errorf("expected %f/%f, got %f/%f", expectedLatitude, expectedLongitude, latitude, longitude)
+	}
+
+	cluster.ChargePoints[0].Lat, cluster.ChargePoints[0].Long = 0, 0
+	if latitude, longitude, _ := cluster.GetLocation(); latitude != cluster.ChargePoints[1].Lat || longitude != cluster.ChargePoints[1].Long {
+		t.Errorf("expected location of second charge point, got %f/%f", latitude, longitude)
+	}
+}
+
+func TestIsOffline(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	threshold := 15 * time.Minute
+	if isOffline(true, now.Add(-time.Hour), now, threshold) {
+		t.Error("expected online charge point not to be offline")
+	}
+	if isOffline(false, now.Add(-10*time.Minute), now, threshold) {
+		t.Error("expected charge point offline shorter than threshold not to be offline")
+	}
+	if !isOffline(false, now.Add(-20*time.Minute), now, threshold) {
+		t.Error("expected charge point offline longer than threshold to be offline")
+	}
+}
+
+func TestIncludedConnectors(t *testing.T) {
+	clusters, _, _ := gp_jouletest.Fixtures()
+	clusters[0].ChargePoints = clusters[0].ChargePoints[:1]
+	included := &appdb.Asset{ProviderID: "con-1-1"}
+	excluded := &appdb.Asset{ProviderID: "con-2-1"}
+
+	dbConnectorAssets := includedConnectors(appdb.AssetSlice{included, excluded}, clusters)
+	if len(dbConnectorAssets) != 1 || dbConnectorAssets[0] != included {
+		t.Errorf("unexpected connectors %v", dbConnectorAssets)
+	}
+}
+
+func TestSendSessions(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	client := fakeClient()
+	store := newMemStore()
+
+	if err := sendSessions(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, data := range store.data {
+		got = append(got, fmt.Sprintf("%d:%s@%s", data.AssetId, data.Subtype, data.Timestamp.Get().Format("01-02T15")))
+	}
+
+	// each session is sent to the session log of its connector and summed up on the charge point, s-2 without
+	// energy is skipped
+	expected := []string{
+		"112:input@04-01T10", "112:info@04-01T10", "10:input@04-01T10",
+		"111:input@04-03T09", "111:info@04-03T09", "10:input@04-03T09",
+		"121:input@04-02T11", "121:info@04-02T11", "20:input@04-02T11",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("unexpected data %v", got)
+	}
+	if dbConnectorAsset := store.assets[11]; dbConnectorAsset.LatestSessionID != "s-3" {
+		t.Errorf("unexpected latest session %s", dbConnectorAsset.LatestSessionID)
+	}
+
+	// sessions sent are skipped on the next run
+	store.data = nil
+	if err := sendSessions(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.data) != 0 {
+		t.Errorf("unexpected data sent again %v", store.data)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	client := fakeClient()
+	store := newMemStore()
+
+	errorCounts := func() []string {
+		var counts []string
+		for _, data := range store.data {
+			counts = append(counts, fmt.Sprintf("%d:%v", data.AssetId, data.Data["error"]))
+		}
+		store.data = nil
+		return counts
+	}
+
+	if err := sendErrors(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := errorCounts(); !slices.Equal(got, []string{"11:1", "11:0", "10:1", "12:1", "21:1"}) {
+		t.Errorf("unexpected error counts %v", got)
+	}
+
+	// errors still open are read again without sending them twice
+	if err := sendErrors(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := errorCounts(); len(got) != 0 {
+		t.Errorf("unexpected error counts sent again %v", got)
+	}
+
+	// the resolution of an error open is sent once
+	for _, notification := range client.ErrorNotifications {
+		if notification.Id == "e-3" {
+			notification.ResolvedAt = common.Ptr(time.Date(2024, 4, 4, 7, 0, 0, 0, time.UTC))
+		}
+	}
+	if err := sendErrors(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := errorCounts(); !slices.Equal(got, []string{"12:0"}) {
+		t.Errorf("unexpected error counts after resolution %v", got)
+	}
+	if store.errors["e-3"].ResolvedAt.IsZero() {
+		t.Errorf("resolution of e-3 not recorded")
+	}
+}
+
+func TestSendSessionsPartialFailure(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	client := fakeClient()
+	store := newMemStore()
+
+	// the charge point fails to receive the first session after its session log got it
+	store.failData = func(data api.Data) bool {
+		return data.AssetId == 10
+	}
+	if err := sendSessions(config, client, store, client.Clusters); err == nil {
+		t.Fatalf("expected error")
+	}
+	if dbSession := store.sessions["s-1"]; dbSession == nil || !slices.Equal(dbSession.Delivered, []string{"112/input", "112/info"}) {
+		t.Errorf("unexpected session recorded %v", dbSession)
+	}
+	if dbConnectorAsset := store.assets[12]; dbConnectorAsset.LatestSessionID != "" {
+		t.Errorf("cursor advanced to %s before the session was delivered", dbConnectorAsset.LatestSessionID)
+	}
+
+	// only the data missing is sent again
+	store.failData = nil
+	store.data = nil
+	if err := sendSessions(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, data := range store.data {
+		got = append(got, fmt.Sprintf("%d:%s@%s", data.AssetId, data.Subtype, data.Timestamp.Get().Format("01-02T15")))
+	}
+	expected := []string{
+		"10:input@04-01T10",
+		"111:input@04-03T09", "111:info@04-03T09", "10:input@04-03T09",
+		"121:input@04-02T11", "121:info@04-02T11", "20:input@04-02T11",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("unexpected data %v", got)
+	}
+	if dbConnectorAsset := store.assets[12]; dbConnectorAsset.LatestSessionID != "s-1" {
+		t.Errorf("unexpected latest session %s", dbConnectorAsset.LatestSessionID)
+	}
+}
+
+func TestSendToSeveralProjects(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	client := fakeClient()
+	store := newMemStore()
+	store.addProject("2", 1000)
+
+	// sessions and errors are sent to the assets in both projects
+	if err := sendSessions(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sendErrors(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sent := make(map[int32]int)
+	for _, data := range store.data {
+		sent[data.AssetId]++
+	}
+	for _, assetId := range []int32{10, 11, 12, 20, 21, 111, 112, 121} {
+		if sent[assetId] == 0 || sent[assetId] != sent[assetId+1000] {
+			t.Errorf("unexpected data sent to asset %d: %d, in second project: %d", assetId, sent[assetId], sent[assetId+1000])
+		}
+	}
+	if store.assets[11].LatestSessionID != "s-3" || store.assets[1011].LatestSessionID != "s-3" {
+		t.Errorf("unexpected latest sessions %s and %s", store.assets[11].LatestSessionID, store.assets[1011].LatestSessionID)
+	}
+}
+
+func TestSendContinuesAfterEmptyReads(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1), SessionOverlap: common.Ptr[int32](3600)}
+	clusters, _, _ := gp_jouletest.Fixtures()
+	client := &gp_joule.FakeClient{Clusters: clusters}
+	store := newMemStore()
+
+	for run := 1; run <= 2; run++ {
+		if err := sendSessions(config, client, store, client.Clusters); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := sendErrors(config, client, store, client.Clusters); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the second run continues where the first one read until, although there was no data
+	if from := client.SessionsReadFrom["cp-1"]; time.Since(from) > time.Hour+time.Minute {
+		t.Errorf("sessions read again from %v", from)
+	}
+	if from := client.ErrorsReadFrom["cp-1"]; time.Since(from) > time.Minute {
+		t.Errorf("errors read again from %v", from)
+	}
+}
+
+// failingWindowClient reads the error notifications of the first window up to windowEnd and fails on the next.
+type failingWindowClient struct {
+	*gp_joule.FakeClient
+	windowEnd time.Time
+}
+
+func (c *failingWindowClient) GetErrorNotifications(chargePointId string, from time.Time, handle func([]*model.ErrorNotification, time.Time) error) error {
+	var notifications []*model.ErrorNotification
+	for _, notification := range c.ErrorNotifications {
+		if notification.ChargePointId == chargePointId && notification.OccurredAt.Before(c.windowEnd) {
+			notifications = append(notifications, notification)
+		}
+	}
+	if err := handle(notifications, c.windowEnd); err != nil {
+		return err
+	}
+	return fmt.Errorf("reading window after %v failed", c.windowEnd)
+}
+
+func TestSendErrorsKeepsWindowsRead(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	windowEnd := time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC)
+	client := &failingWindowClient{FakeClient: fakeClient(), windowEnd: windowEnd}
+	store := newMemStore()
+
+	if err := sendErrors(config, client, store, client.Clusters); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// the errors of the first window and the cursor are stored despite the failure
+	if store.errors["e-1"] == nil || store.errors["e-2"] == nil || store.errors["e-3"] != nil {
+		t.Errorf("unexpected errors stored %v", store.errors)
+	}
+	for _, assetId := range []int64{10, 11, 12} {
+		if readUntil := store.assets[assetId].ErrorsReadUntil; !readUntil.Equal(windowEnd) {
+			t.Errorf("asset %d: errors read until %v, expected %v", assetId, readUntil, windowEnd)
+		}
+	}
+}
+
+func TestSendErrorsOfChargePointWithoutConnectors(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1)}
+	client := fakeClient()
+	store := newMemStore()
+
+	// cp-1 is included without any of its connectors
+	client.Clusters[0].ChargePoints[0].Connectors = nil
+
+	if err := sendErrors(config, client, store, client.Clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, data := range store.data {
+		got = append(got, fmt.Sprintf("%d:%v", data.AssetId, data.Data["error"]))
+	}
+
+	// the error without connector counts on the charge point
+	if !slices.Equal(got, []string{"10:1", "21:1"}) {
+		t.Errorf("unexpected error counts %v", got)
+	}
+}
@@ -24,72 +24,117 @@ import (
 
 // Asset is an object representing the database table.
 type Asset struct {
-	ID               int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	ConfigurationID  int64       `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
-	ProjectID        string      `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
-	GlobalAssetID    string      `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
-	ParentProviderID string      `boil:"parent_provider_id" json:"parent_provider_id" toml:"parent_provider_id" yaml:"parent_provider_id"`
-	ProviderID       string      `boil:"provider_id" json:"provider_id" toml:"provider_id" yaml:"provider_id"`
-	AssetID          null.Int32  `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
-	AssetType        null.String `boil:"asset_type" json:"asset_type,omitempty" toml:"asset_type" yaml:"asset_type,omitempty"`
-	InitVersion      int32       `boil:"init_version" json:"init_version" toml:"init_version" yaml:"init_version"`
-	LatestSessionTS  time.Time   `boil:"latest_session_ts" json:"latest_session_ts" toml:"latest_session_ts" yaml:"latest_session_ts"`
-	LatestErrorTS    time.Time   `boil:"latest_error_ts" json:"latest_error_ts" toml:"latest_error_ts" yaml:"latest_error_ts"`
+	ID                int64        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ConfigurationID   int64        `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	ProjectID         string       `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	GlobalAssetID     string       `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
+	ParentProviderID  string       `boil:"parent_provider_id" json:"parent_provider_id" toml:"parent_provider_id" yaml:"parent_provider_id"`
+	ProviderID        string       `boil:"provider_id" json:"provider_id" toml:"provider_id" yaml:"provider_id"`
+	AssetID           null.Int32   `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
+	AssetType         null.String  `boil:"asset_type" json:"asset_type,omitempty" toml:"asset_type" yaml:"asset_type,omitempty"`
+	InitVersion       int32        `boil:"init_version" json:"init_version" toml:"init_version" yaml:"init_version"`
+	LatestSessionTS   time.Time    `boil:"latest_session_ts" json:"latest_session_ts" toml:"latest_session_ts" yaml:"latest_session_ts"`
+	LatestSessionID   string       `boil:"latest_session_id" json:"latest_session_id" toml:"latest_session_id" yaml:"latest_session_id"`
+	LatestErrorTS     time.Time    `boil:"latest_error_ts" json:"latest_error_ts" toml:"latest_error_ts" yaml:"latest_error_ts"`
+	Latitude          null.Float64 `boil:"latitude" json:"latitude,omitempty" toml:"latitude" yaml:"latitude,omitempty"`
+	Longitude         null.Float64 `boil:"longitude" json:"longitude,omitempty" toml:"longitude" yaml:"longitude,omitempty"`
+	LastSeen          time.Time    `boil:"last_seen" json:"last_seen" toml:"last_seen" yaml:"last_seen"`
+	RemovedAt         null.Time    `boil:"removed_at" json:"removed_at,omitempty" toml:"removed_at" yaml:"removed_at,omitempty"`
+	ConnectorIndex    null.Int32   `boil:"connector_index" json:"connector_index,omitempty" toml:"connector_index" yaml:"connector_index,omitempty"`
+	Name              null.String  `boil:"name" json:"name,omitempty" toml:"name" yaml:"name,omitempty"`
+	SessionsReadUntil time.Time    `boil:"sessions_read_until" json:"sessions_read_until" toml:"sessions_read_until" yaml:"sessions_read_until"`
+	ErrorsReadUntil   time.Time    `boil:"errors_read_until" json:"errors_read_until" toml:"errors_read_until" yaml:"errors_read_until"`
 
 	R *assetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L assetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AssetColumns = struct {
-	ID               string
-	ConfigurationID  string
-	ProjectID        string
-	GlobalAssetID    string
-	ParentProviderID string
-	ProviderID       string
-	AssetID          string
-	AssetType        string
-	InitVersion      string
-	LatestSessionTS  string
-	LatestErrorTS    string
+	ID                string
+	ConfigurationID   string
+	ProjectID         string
+	GlobalAssetID     string
+	ParentProviderID  string
+	ProviderID        string
+	AssetID           string
+	AssetType         string
+	InitVersion       string
+	LatestSessionTS   string
+	LatestSessionID   string
+	LatestErrorTS     string
+	Latitude          string
+	Longitude         string
+	LastSeen          string
+	RemovedAt         string
+	ConnectorIndex    string
+	Name              string
+	SessionsReadUntil string
+	ErrorsReadUntil   string
 }{
-	ID:               "id",
-	ConfigurationID:  "configuration_id",
-	ProjectID:        "project_id",
-	GlobalAssetID:    "global_asset_id",
-	ParentProviderID: "parent_provider_id",
-	ProviderID:       "provider_id",
-	AssetID:          "asset_id",
-	AssetType:        "asset_type",
-	InitVersion:      "init_version",
-	LatestSessionTS:  "latest_session_ts",
-	LatestErrorTS:    "latest_error_ts",
+	ID:                "id",
+	ConfigurationID:   "configuration_id",
+	ProjectID:         "project_id",
+	GlobalAssetID:     "global_asset_id",
+	ParentProviderID:  "parent_provider_id",
+	ProviderID:        "provider_id",
+	AssetID:           "asset_id",
+	AssetType:         "asset_type",
+	InitVersion:       "init_version",
+	LatestSessionTS:   "latest_session_ts",
+	LatestSessionID:   "latest_session_id",
+	LatestErrorTS:     "latest_error_ts",
+	Latitude:          "latitude",
+	Longitude:         "longitude",
+	LastSeen:          "last_seen",
+	RemovedAt:         "removed_at",
+	ConnectorIndex:    "connector_index",
+	Name:              "name",
+	SessionsReadUntil: "sessions_read_until",
+	ErrorsReadUntil:   "errors_read_until",
 }
 
 var AssetTableColumns = struct {
-	ID               string
-	ConfigurationID  string
-	ProjectID        string
-	GlobalAssetID    string
-	ParentProviderID string
-	ProviderID       string
-	AssetID          string
-	AssetType        string
-	InitVersion      string
-	LatestSessionTS  string
-	LatestErrorTS    string
+	ID                string
+	ConfigurationID   string
+	ProjectID         string
+	GlobalAssetID     string
+	ParentProviderID  string
+	ProviderID        string
+	AssetID           string
+	AssetType         string
+	InitVersion       string
+	LatestSessionTS   string
+	LatestSessionID   string
+	LatestErrorTS     string
+	Latitude          string
+	Longitude         string
+	LastSeen          string
+	RemovedAt         string
+	ConnectorIndex    string
+	Name              string
+	SessionsReadUntil string
+	ErrorsReadUntil   string
 }{
-	ID:               "asset.id",
-	ConfigurationID:  "asset.configuration_id",
-	ProjectID:        "asset.project_id",
-	GlobalAssetID:    "asset.global_asset_id",
-	ParentProviderID: "asset.parent_provider_id",
-	ProviderID:       "asset.provider_id",
-	AssetID:          "asset.asset_id",
-	AssetType:        "asset.asset_type",
-	InitVersion:      "asset.init_version",
-	LatestSessionTS:  "asset.latest_session_ts",
-	LatestErrorTS:    "asset.latest_error_ts",
+	ID:                "asset.id",
+	ConfigurationID:   "asset.configuration_id",
+	ProjectID:         "asset.project_id",
+	GlobalAssetID:     "asset.global_asset_id",
+	ParentProviderID:  "asset.parent_provider_id",
+	ProviderID:        "asset.provider_id",
+	AssetID:           "asset.asset_id",
+	AssetType:         "asset.asset_type",
+	InitVersion:       "asset.init_version",
+	LatestSessionTS:   "asset.latest_session_ts",
+	LatestSessionID:   "asset.latest_session_id",
+	LatestErrorTS:     "asset.latest_error_ts",
+	Latitude:          "asset.latitude",
+	Longitude:         "asset.longitude",
+	LastSeen:          "asset.last_seen",
+	RemovedAt:         "asset.removed_at",
+	ConnectorIndex:    "asset.connector_index",
+	Name:              "asset.name",
+	SessionsReadUntil: "asset.sessions_read_until",
+	ErrorsReadUntil:   "asset.errors_read_until",
 }
 
 // Generated where
@@ -276,30 +321,110 @@ func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_Float64 struct{ field string }
+
+func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Float64) LTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var AssetWhere = struct {
-	ID               whereHelperint64
-	ConfigurationID  whereHelperint64
-	ProjectID        whereHelperstring
-	GlobalAssetID    whereHelperstring
-	ParentProviderID whereHelperstring
-	ProviderID       whereHelperstring
-	AssetID          whereHelpernull_Int32
-	AssetType        whereHelpernull_String
-	InitVersion      whereHelperint32
-	LatestSessionTS  whereHelpertime_Time
-	LatestErrorTS    whereHelpertime_Time
+	ID                whereHelperint64
+	ConfigurationID   whereHelperint64
+	ProjectID         whereHelperstring
+	GlobalAssetID     whereHelperstring
+	ParentProviderID  whereHelperstring
+	ProviderID        whereHelperstring
+	AssetID           whereHelpernull_Int32
+	AssetType         whereHelpernull_String
+	InitVersion       whereHelperint32
+	LatestSessionTS   whereHelpertime_Time
+	LatestSessionID   whereHelperstring
+	LatestErrorTS     whereHelpertime_Time
+	Latitude          whereHelpernull_Float64
+	Longitude         whereHelpernull_Float64
+	LastSeen          whereHelpertime_Time
+	RemovedAt         whereHelpernull_Time
+	ConnectorIndex    whereHelpernull_Int32
+	Name              whereHelpernull_String
+	SessionsReadUntil whereHelpertime_Time
+	ErrorsReadUntil   whereHelpertime_Time
 }{
-	ID:               whereHelperint64{field: "\"gp_joule\".\"asset\".\"id\""},
-	ConfigurationID:  whereHelperint64{field: "\"gp_joule\".\"asset\".\"configuration_id\""},
-	ProjectID:        whereHelperstring{field: "\"gp_joule\".\"asset\".\"project_id\""},
-	GlobalAssetID:    whereHelperstring{field: "\"gp_joule\".\"asset\".\"global_asset_id\""},
-	ParentProviderID: whereHelperstring{field: "\"gp_joule\".\"asset\".\"parent_provider_id\""},
-	ProviderID:       whereHelperstring{field: "\"gp_joule\".\"asset\".\"provider_id\""},
-	AssetID:          whereHelpernull_Int32{field: "\"gp_joule\".\"asset\".\"asset_id\""},
-	AssetType:        whereHelpernull_String{field: "\"gp_joule\".\"asset\".\"asset_type\""},
-	InitVersion:      whereHelperint32{field: "\"gp_joule\".\"asset\".\"init_version\""},
-	LatestSessionTS:  whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"latest_session_ts\""},
-	LatestErrorTS:    whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"latest_error_ts\""},
+	ID:                whereHelperint64{field: "\"gp_joule\".\"asset\".\"id\""},
+	ConfigurationID:   whereHelperint64{field: "\"gp_joule\".\"asset\".\"configuration_id\""},
+	ProjectID:         whereHelperstring{field: "\"gp_joule\".\"asset\".\"project_id\""},
+	GlobalAssetID:     whereHelperstring{field: "\"gp_joule\".\"asset\".\"global_asset_id\""},
+	ParentProviderID:  whereHelperstring{field: "\"gp_joule\".\"asset\".\"parent_provider_id\""},
+	ProviderID:        whereHelperstring{field: "\"gp_joule\".\"asset\".\"provider_id\""},
+	AssetID:           whereHelpernull_Int32{field: "\"gp_joule\".\"asset\".\"asset_id\""},
+	AssetType:         whereHelpernull_String{field: "\"gp_joule\".\"asset\".\"asset_type\""},
+	InitVersion:       whereHelperint32{field: "\"gp_joule\".\"asset\".\"init_version\""},
+	LatestSessionTS:   whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"latest_session_ts\""},
+	LatestSessionID:   whereHelperstring{field: "\"gp_joule\".\"asset\".\"latest_session_id\""},
+	LatestErrorTS:     whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"latest_error_ts\""},
+	Latitude:          whereHelpernull_Float64{field: "\"gp_joule\".\"asset\".\"latitude\""},
+	Longitude:         whereHelpernull_Float64{field: "\"gp_joule\".\"asset\".\"longitude\""},
+	LastSeen:          whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"last_seen\""},
+	RemovedAt:         whereHelpernull_Time{field: "\"gp_joule\".\"asset\".\"removed_at\""},
+	ConnectorIndex:    whereHelpernull_Int32{field: "\"gp_joule\".\"asset\".\"connector_index\""},
+	Name:              whereHelpernull_String{field: "\"gp_joule\".\"asset\".\"name\""},
+	SessionsReadUntil: whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"sessions_read_until\""},
+	ErrorsReadUntil:   whereHelpertime_Time{field: "\"gp_joule\".\"asset\".\"errors_read_until\""},
 }
 
 // AssetRels is where relationship names are stored.
@@ -330,9 +455,9 @@ func (r *assetR) GetConfiguration() *Configuration {
 type assetL struct{}
 
 var (
-	assetAllColumns            = []string{"id", "configuration_id", "project_id", "global_asset_id", "parent_provider_id", "provider_id", "asset_id", "asset_type", "init_version", "latest_session_ts", "latest_error_ts"}
+	assetAllColumns            = []string{"id", "configuration_id", "project_id", "global_asset_id", "parent_provider_id", "provider_id", "asset_id", "asset_type", "init_version", "latest_session_ts", "latest_session_id", "latest_error_ts", "latitude", "longitude", "last_seen", "removed_at", "connector_index", "name", "sessions_read_until", "errors_read_until"}
 	assetColumnsWithoutDefault = []string{"project_id", "global_asset_id", "parent_provider_id", "provider_id"}
-	assetColumnsWithDefault    = []string{"id", "configuration_id", "asset_id", "asset_type", "init_version", "latest_session_ts", "latest_error_ts"}
+	assetColumnsWithDefault    = []string{"id", "configuration_id", "asset_id", "asset_type", "init_version", "latest_session_ts", "latest_session_id", "latest_error_ts", "latitude", "longitude", "last_seen", "removed_at", "connector_index", "name", "sessions_read_until", "errors_read_until"}
 	assetPrimaryKeyColumns     = []string{"id"}
 	assetGeneratedColumns      = []string{}
 )
@@ -6,7 +6,11 @@ package appdb
 var TableNames = struct {
 	Asset         string
 	Configuration string
+	Error         string
+	Session       string
 }{
 	Asset:         "asset",
 	Configuration: "configuration",
+	Error:         "error",
+	Session:       "session",
 }
@@ -25,67 +25,117 @@ import (
 
 // Configuration is an object representing the database table.
 type Configuration struct {
-	ID              int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
-	RootURL         string            `boil:"root_url" json:"root_url" toml:"root_url" yaml:"root_url"`
-	APIKey          string            `boil:"api_key" json:"api_key" toml:"api_key" yaml:"api_key"`
-	RefreshInterval int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
-	RequestTimeout  int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
-	AssetFilter     null.JSON         `boil:"asset_filter" json:"asset_filter,omitempty" toml:"asset_filter" yaml:"asset_filter,omitempty"`
-	Active          null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
-	Enable          null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	ProjectIds      types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
-	UserID          null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ID               int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	RootURL          string            `boil:"root_url" json:"root_url" toml:"root_url" yaml:"root_url"`
+	APIKey           string            `boil:"api_key" json:"api_key" toml:"api_key" yaml:"api_key"`
+	RefreshInterval  int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
+	RequestTimeout   int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
+	MaxRetries       int32             `boil:"max_retries" json:"max_retries" toml:"max_retries" yaml:"max_retries"`
+	RateLimit        int32             `boil:"rate_limit" json:"rate_limit" toml:"rate_limit" yaml:"rate_limit"`
+	OfflineThreshold int32             `boil:"offline_threshold" json:"offline_threshold" toml:"offline_threshold" yaml:"offline_threshold"`
+	SessionOverlap   int32             `boil:"session_overlap" json:"session_overlap" toml:"session_overlap" yaml:"session_overlap"`
+	RemovalPolicy    string            `boil:"removal_policy" json:"removal_policy" toml:"removal_policy" yaml:"removal_policy"`
+	NameTemplates    null.JSON         `boil:"name_templates" json:"name_templates,omitempty" toml:"name_templates" yaml:"name_templates,omitempty"`
+	RenameAssets     bool              `boil:"rename_assets" json:"rename_assets" toml:"rename_assets" yaml:"rename_assets"`
+	ProjectMappings  null.JSON         `boil:"project_mappings" json:"project_mappings,omitempty" toml:"project_mappings" yaml:"project_mappings,omitempty"`
+	ErrorCodes       null.JSON         `boil:"error_codes" json:"error_codes,omitempty" toml:"error_codes" yaml:"error_codes,omitempty"`
+	ErrorLanguage    string            `boil:"error_language" json:"error_language" toml:"error_language" yaml:"error_language"`
+	AssetFilter      null.JSON         `boil:"asset_filter" json:"asset_filter,omitempty" toml:"asset_filter" yaml:"asset_filter,omitempty"`
+	Active           null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
+	Enable           null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	ProjectIds       types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
+	UserID           null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
 
 	R *configurationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configurationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigurationColumns = struct {
-	ID              string
-	RootURL         string
-	APIKey          string
-	RefreshInterval string
-	RequestTimeout  string
-	AssetFilter     string
-	Active          string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID               string
+	RootURL          string
+	APIKey           string
+	RefreshInterval  string
+	RequestTimeout   string
+	MaxRetries       string
+	RateLimit        string
+	OfflineThreshold string
+	SessionOverlap   string
+	RemovalPolicy    string
+	NameTemplates    string
+	RenameAssets     string
+	ProjectMappings  string
+	ErrorCodes       string
+	ErrorLanguage    string
+	AssetFilter      string
+	Active           string
+	Enable           string
+	ProjectIds       string
+	UserID           string
 }{
-	ID:              "id",
-	RootURL:         "root_url",
-	APIKey:          "api_key",
-	RefreshInterval: "refresh_interval",
-	RequestTimeout:  "request_timeout",
-	AssetFilter:     "asset_filter",
-	Active:          "active",
-	Enable:          "enable",
-	ProjectIds:      "project_ids",
-	UserID:          "user_id",
+	ID:               "id",
+	RootURL:          "root_url",
+	APIKey:           "api_key",
+	RefreshInterval:  "refresh_interval",
+	RequestTimeout:   "request_timeout",
+	MaxRetries:       "max_retries",
+	RateLimit:        "rate_limit",
+	OfflineThreshold: "offline_threshold",
+	SessionOverlap:   "session_overlap",
+	RemovalPolicy:    "removal_policy",
+	NameTemplates:    "name_templates",
+	RenameAssets:     "rename_assets",
+	ProjectMappings:  "project_mappings",
+	ErrorCodes:       "error_codes",
+	ErrorLanguage:    "error_language",
+	AssetFilter:      "asset_filter",
+	Active:           "active",
+	Enable:           "enable",
+	ProjectIds:       "project_ids",
+	UserID:           "user_id",
 }
 
 var ConfigurationTableColumns = struct {
-	ID              string
-	RootURL         string
-	APIKey          string
-	RefreshInterval string
-	RequestTimeout  string
-	AssetFilter     string
-	Active          string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID               string
+	RootURL          string
+	APIKey           string
+	RefreshInterval  string
+	RequestTimeout   string
+	MaxRetries       string
+	RateLimit        string
+	OfflineThreshold string
+	SessionOverlap   string
+	RemovalPolicy    string
+	NameTemplates    string
+	RenameAssets     string
+	ProjectMappings  string
+	ErrorCodes       string
+	ErrorLanguage    string
+	AssetFilter      string
+	Active           string
+	Enable           string
+	ProjectIds       string
+	UserID           string
 }{
-	ID:              "configuration.id",
-	RootURL:         "configuration.root_url",
-	APIKey:          "configuration.api_key",
-	RefreshInterval: "configuration.refresh_interval",
-	RequestTimeout:  "configuration.request_timeout",
-	AssetFilter:     "configuration.asset_filter",
-	Active:          "configuration.active",
-	Enable:          "configuration.enable",
-	ProjectIds:      "configuration.project_ids",
-	UserID:          "configuration.user_id",
+	ID:               "configuration.id",
+	RootURL:          "configuration.root_url",
+	APIKey:           "configuration.api_key",
+	RefreshInterval:  "configuration.refresh_interval",
+	RequestTimeout:   "configuration.request_timeout",
+	MaxRetries:       "configuration.max_retries",
+	RateLimit:        "configuration.rate_limit",
+	OfflineThreshold: "configuration.offline_threshold",
+	SessionOverlap:   "configuration.session_overlap",
+	RemovalPolicy:    "configuration.removal_policy",
+	NameTemplates:    "configuration.name_templates",
+	RenameAssets:     "configuration.rename_assets",
+	ProjectMappings:  "configuration.project_mappings",
+	ErrorCodes:       "configuration.error_codes",
+	ErrorLanguage:    "configuration.error_language",
+	AssetFilter:      "configuration.asset_filter",
+	Active:           "configuration.active",
+	Enable:           "configuration.enable",
+	ProjectIds:       "configuration.project_ids",
+	UserID:           "configuration.user_id",
 }
 
 // Generated where
@@ -114,6 +164,15 @@ func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
 func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 type whereHelpernull_Bool struct{ field string }
 
 func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
@@ -165,39 +224,65 @@ func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 }
 
 var ConfigurationWhere = struct {
-	ID              whereHelperint64
-	RootURL         whereHelperstring
-	APIKey          whereHelperstring
-	RefreshInterval whereHelperint32
-	RequestTimeout  whereHelperint32
-	AssetFilter     whereHelpernull_JSON
-	Active          whereHelpernull_Bool
-	Enable          whereHelpernull_Bool
-	ProjectIds      whereHelpertypes_StringArray
-	UserID          whereHelpernull_String
+	ID               whereHelperint64
+	RootURL          whereHelperstring
+	APIKey           whereHelperstring
+	RefreshInterval  whereHelperint32
+	RequestTimeout   whereHelperint32
+	MaxRetries       whereHelperint32
+	RateLimit        whereHelperint32
+	OfflineThreshold whereHelperint32
+	SessionOverlap   whereHelperint32
+	RemovalPolicy    whereHelperstring
+	NameTemplates    whereHelpernull_JSON
+	RenameAssets     whereHelperbool
+	ProjectMappings  whereHelpernull_JSON
+	ErrorCodes       whereHelpernull_JSON
+	ErrorLanguage    whereHelperstring
+	AssetFilter      whereHelpernull_JSON
+	Active           whereHelpernull_Bool
+	Enable           whereHelpernull_Bool
+	ProjectIds       whereHelpertypes_StringArray
+	UserID           whereHelpernull_String
 }{
-	ID:              whereHelperint64{field: "\"gp_joule\".\"configuration\".\"id\""},
-	RootURL:         whereHelperstring{field: "\"gp_joule\".\"configuration\".\"root_url\""},
-	APIKey:          whereHelperstring{field: "\"gp_joule\".\"configuration\".\"api_key\""},
-	RefreshInterval: whereHelperint32{field: "\"gp_joule\".\"configuration\".\"refresh_interval\""},
-	RequestTimeout:  whereHelperint32{field: "\"gp_joule\".\"configuration\".\"request_timeout\""},
-	AssetFilter:     whereHelpernull_JSON{field: "\"gp_joule\".\"configuration\".\"asset_filter\""},
-	Active:          whereHelpernull_Bool{field: "\"gp_joule\".\"configuration\".\"active\""},
-	Enable:          whereHelpernull_Bool{field: "\"gp_joule\".\"configuration\".\"enable\""},
-	ProjectIds:      whereHelpertypes_StringArray{field: "\"gp_joule\".\"configuration\".\"project_ids\""},
-	UserID:          whereHelpernull_String{field: "\"gp_joule\".\"configuration\".\"user_id\""},
+	ID:               whereHelperint64{field: "\"gp_joule\".\"configuration\".\"id\""},
+	RootURL:          whereHelperstring{field: "\"gp_joule\".\"configuration\".\"root_url\""},
+	APIKey:           whereHelperstring{field: "\"gp_joule\".\"configuration\".\"api_key\""},
+	RefreshInterval:  whereHelperint32{field: "\"gp_joule\".\"configuration\".\"refresh_interval\""},
+	RequestTimeout:   whereHelperint32{field: "\"gp_joule\".\"configuration\".\"request_timeout\""},
+	MaxRetries:       whereHelperint32{field: "\"gp_joule\".\"configuration\".\"max_retries\""},
+	RateLimit:        whereHelperint32{field: "\"gp_joule\".\"configuration\".\"rate_limit\""},
+	OfflineThreshold: whereHelperint32{field: "\"gp_joule\".\"configuration\".\"offline_threshold\""},
+	SessionOverlap:   whereHelperint32{field: "\"gp_joule\".\"configuration\".\"session_overlap\""},
+	RemovalPolicy:    whereHelperstring{field: "\"gp_joule\".\"configuration\".\"removal_policy\""},
+	NameTemplates:    whereHelpernull_JSON{field: "\"gp_joule\".\"configuration\".\"name_templates\""},
+	RenameAssets:     whereHelperbool{field: "\"gp_joule\".\"configuration\".\"rename_assets\""},
+	ProjectMappings:  whereHelpernull_JSON{field: "\"gp_joule\".\"configuration\".\"project_mappings\""},
+	ErrorCodes:       whereHelpernull_JSON{field: "\"gp_joule\".\"configuration\".\"error_codes\""},
+	ErrorLanguage:    whereHelperstring{field: "\"gp_joule\".\"configuration\".\"error_language\""},
+	AssetFilter:      whereHelpernull_JSON{field: "\"gp_joule\".\"configuration\".\"asset_filter\""},
+	Active:           whereHelpernull_Bool{field: "\"gp_joule\".\"configuration\".\"active\""},
+	Enable:           whereHelpernull_Bool{field: "\"gp_joule\".\"configuration\".\"enable\""},
+	ProjectIds:       whereHelpertypes_StringArray{field: "\"gp_joule\".\"configuration\".\"project_ids\""},
+	UserID:           whereHelpernull_String{field: "\"gp_joule\".\"configuration\".\"user_id\""},
 }
 
 // ConfigurationRels is where relationship names are stored.
 var ConfigurationRels = struct {
-	Assets string
+	Assets   string
+	Errors   string
+	Sessions string
 }{
-	Assets: "Assets",
+	Assets:   "Assets",
+	Errors:   "Errors",
+	Sessions: "Sessions",
 }
 
 // configurationR is where relationships are stored.
 type configurationR struct {
-	Assets AssetSlice `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	Assets   AssetSlice   `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	Errors   ErrorSlice   `boil:"Errors" json:"Errors" toml:"Errors" yaml:"Errors"`
+	Sessions SessionSlice `boil:"Sessions" json:"Sessions" toml:"Sessions" yaml:"Sessions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -212,13 +297,27 @@ func (r *configurationR) GetAssets() AssetSlice {
 	return r.Assets
 }
 
+func (r *configurationR) GetErrors() ErrorSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Errors
+}
+
+func (r *configurationR) GetSessions() SessionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Sessions
+}
+
 // configurationL is where Load methods for each relationship are stored.
 type configurationL struct{}
 
 var (
-	configurationAllColumns            = []string{"id", "root_url", "api_key", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id"}
+	configurationAllColumns            = []string{"id", "root_url", "api_key", "refresh_interval", "request_timeout", "max_retries", "rate_limit", "offline_threshold", "session_overlap", "removal_policy", "name_templates", "rename_assets", "project_mappings", "error_codes", "error_language", "asset_filter", "active", "enable", "project_ids", "user_id"}
 	configurationColumnsWithoutDefault = []string{"root_url", "api_key"}
-	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id"}
+	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "max_retries", "rate_limit", "offline_threshold", "session_overlap", "removal_policy", "name_templates", "rename_assets", "project_mappings", "error_codes", "error_language", "asset_filter", "active", "enable", "project_ids", "user_id"}
 	configurationPrimaryKeyColumns     = []string{"id"}
 	configurationGeneratedColumns      = []string{}
 )
@@ -562,6 +661,34 @@ func (o *Configuration) Assets(mods ...qm.QueryMod) assetQuery {
 	return Assets(queryMods...)
 }
 
+// Errors retrieves all the error's Errors with an executor.
+func (o *Configuration) Errors(mods ...qm.QueryMod) errorQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"gp_joule\".\"error\".\"configuration_id\"=?", o.ID),
+	)
+
+	return Errors(queryMods...)
+}
+
+// Sessions retrieves all the session's Sessions with an executor.
+func (o *Configuration) Sessions(mods ...qm.QueryMod) sessionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"gp_joule\".\"session\".\"configuration_id\"=?", o.ID),
+	)
+
+	return Sessions(queryMods...)
+}
+
 // LoadAssets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
@@ -675,6 +802,232 @@ func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
+// LoadErrors allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadErrors(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`gp_joule.error`),
+		qm.WhereIn(`gp_joule.error.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load error")
+	}
+
+	var resultSlice []*Error
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice error")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on error")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for error")
+	}
+
+	if len(errorAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Errors = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &errorR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.Errors = append(local.R.Errors, foreign)
+				if foreign.R == nil {
+					foreign.R = &errorR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSessions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadSessions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`gp_joule.session`),
+		qm.WhereIn(`gp_joule.session.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load session")
+	}
+
+	var resultSlice []*Session
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice session")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on session")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for session")
+	}
+
+	if len(sessionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Sessions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &sessionR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.Sessions = append(local.R.Sessions, foreign)
+				if foreign.R == nil {
+					foreign.R = &sessionR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddAssetsG adds the given related objects to the existing relationships
 // of the configuration, optionally inserting them as new records.
 // Appends related to o.R.Assets.
@@ -737,6 +1090,130 @@ func (o *Configuration) AddAssets(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
+// AddErrorsG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Errors.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddErrorsG(ctx context.Context, insert bool, related ...*Error) error {
+	return o.AddErrors(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddErrors adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Errors.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddErrors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Error) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"gp_joule\".\"error\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, errorPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ConfigurationID, rel.ErrorID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			Errors: related,
+		}
+	} else {
+		o.R.Errors = append(o.R.Errors, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &errorR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
+// AddSessionsG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Sessions.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddSessionsG(ctx context.Context, insert bool, related ...*Session) error {
+	return o.AddSessions(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddSessions adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.Sessions.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddSessions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Session) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"gp_joule\".\"session\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, sessionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ConfigurationID, rel.SessionID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			Sessions: related,
+		}
+	} else {
+		o.R.Sessions = append(o.R.Sessions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &sessionR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
 // Configurations retrieves all the records using an executor.
 func Configurations(mods ...qm.QueryMod) configurationQuery {
 	mods = append(mods, qm.From("\"gp_joule\".\"configuration\""))
@@ -0,0 +1,1265 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Error is an object representing the database table.
+type Error struct {
+	ConfigurationID int64     `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	ErrorID         string    `boil:"error_id" json:"error_id" toml:"error_id" yaml:"error_id"`
+	ChargePointID   string    `boil:"charge_point_id" json:"charge_point_id" toml:"charge_point_id" yaml:"charge_point_id"`
+	ProviderID      string    `boil:"provider_id" json:"provider_id" toml:"provider_id" yaml:"provider_id"`
+	ErrorCode       string    `boil:"error_code" json:"error_code" toml:"error_code" yaml:"error_code"`
+	ErrorInfo       string    `boil:"error_info" json:"error_info" toml:"error_info" yaml:"error_info"`
+	VendorCode      string    `boil:"vendor_code" json:"vendor_code" toml:"vendor_code" yaml:"vendor_code"`
+	OccurredAt      time.Time `boil:"occurred_at" json:"occurred_at" toml:"occurred_at" yaml:"occurred_at"`
+	ResolvedAt      null.Time `boil:"resolved_at" json:"resolved_at,omitempty" toml:"resolved_at" yaml:"resolved_at,omitempty"`
+
+	R *errorR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L errorL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ErrorColumns = struct {
+	ConfigurationID string
+	ErrorID         string
+	ChargePointID   string
+	ProviderID      string
+	ErrorCode       string
+	ErrorInfo       string
+	VendorCode      string
+	OccurredAt      string
+	ResolvedAt      string
+}{
+	ConfigurationID: "configuration_id",
+	ErrorID:         "error_id",
+	ChargePointID:   "charge_point_id",
+	ProviderID:      "provider_id",
+	ErrorCode:       "error_code",
+	ErrorInfo:       "error_info",
+	VendorCode:      "vendor_code",
+	OccurredAt:      "occurred_at",
+	ResolvedAt:      "resolved_at",
+}
+
+var ErrorTableColumns = struct {
+	ConfigurationID string
+	ErrorID         string
+	ChargePointID   string
+	ProviderID      string
+	ErrorCode       string
+	ErrorInfo       string
+	VendorCode      string
+	OccurredAt      string
+	ResolvedAt      string
+}{
+	ConfigurationID: "error.configuration_id",
+	ErrorID:         "error.error_id",
+	ChargePointID:   "error.charge_point_id",
+	ProviderID:      "error.provider_id",
+	ErrorCode:       "error.error_code",
+	ErrorInfo:       "error.error_info",
+	VendorCode:      "error.vendor_code",
+	OccurredAt:      "error.occurred_at",
+	ResolvedAt:      "error.resolved_at",
+}
+
+// Generated where
+
+var ErrorWhere = struct {
+	ConfigurationID whereHelperint64
+	ErrorID         whereHelperstring
+	ChargePointID   whereHelperstring
+	ProviderID      whereHelperstring
+	ErrorCode       whereHelperstring
+	ErrorInfo       whereHelperstring
+	VendorCode      whereHelperstring
+	OccurredAt      whereHelpertime_Time
+	ResolvedAt      whereHelpernull_Time
+}{
+	ConfigurationID: whereHelperint64{field: "\"gp_joule\".\"error\".\"configuration_id\""},
+	ErrorID:         whereHelperstring{field: "\"gp_joule\".\"error\".\"error_id\""},
+	ChargePointID:   whereHelperstring{field: "\"gp_joule\".\"error\".\"charge_point_id\""},
+	ProviderID:      whereHelperstring{field: "\"gp_joule\".\"error\".\"provider_id\""},
+	ErrorCode:       whereHelperstring{field: "\"gp_joule\".\"error\".\"error_code\""},
+	ErrorInfo:       whereHelperstring{field: "\"gp_joule\".\"error\".\"error_info\""},
+	VendorCode:      whereHelperstring{field: "\"gp_joule\".\"error\".\"vendor_code\""},
+	OccurredAt:      whereHelpertime_Time{field: "\"gp_joule\".\"error\".\"occurred_at\""},
+	ResolvedAt:      whereHelpernull_Time{field: "\"gp_joule\".\"error\".\"resolved_at\""},
+}
+
+// ErrorRels is where relationship names are stored.
+var ErrorRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// errorR is where relationships are stored.
+type errorR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*errorR) NewStruct() *errorR {
+	return &errorR{}
+}
+
+func (r *errorR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// errorL is where Load methods for each relationship are stored.
+type errorL struct{}
+
+var (
+	errorAllColumns            = []string{"configuration_id", "error_id", "charge_point_id", "provider_id", "error_code", "error_info", "vendor_code", "occurred_at", "resolved_at"}
+	errorColumnsWithoutDefault = []string{"configuration_id", "error_id", "charge_point_id", "provider_id", "occurred_at"}
+	errorColumnsWithDefault    = []string{"error_code", "error_info", "vendor_code", "resolved_at"}
+	errorPrimaryKeyColumns     = []string{"configuration_id", "error_id"}
+	errorGeneratedColumns      = []string{}
+)
+
+type (
+	// ErrorSlice is an alias for a slice of pointers to Error.
+	// This should almost always be used instead of []Error.
+	ErrorSlice []*Error
+	// ErrorHook is the signature for custom Error hook methods
+	ErrorHook func(context.Context, boil.ContextExecutor, *Error) error
+
+	errorQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	errorType                 = reflect.TypeOf(&Error{})
+	errorMapping              = queries.MakeStructMapping(errorType)
+	errorPrimaryKeyMapping, _ = queries.BindMapping(errorType, errorMapping, errorPrimaryKeyColumns)
+	errorInsertCacheMut       sync.RWMutex
+	errorInsertCache          = make(map[string]insertCache)
+	errorUpdateCacheMut       sync.RWMutex
+	errorUpdateCache          = make(map[string]updateCache)
+	errorUpsertCacheMut       sync.RWMutex
+	errorUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var errorAfterSelectMu sync.Mutex
+var errorAfterSelectHooks []ErrorHook
+
+var errorBeforeInsertMu sync.Mutex
+var errorBeforeInsertHooks []ErrorHook
+var errorAfterInsertMu sync.Mutex
+var errorAfterInsertHooks []ErrorHook
+
+var errorBeforeUpdateMu sync.Mutex
+var errorBeforeUpdateHooks []ErrorHook
+var errorAfterUpdateMu sync.Mutex
+var errorAfterUpdateHooks []ErrorHook
+
+var errorBeforeDeleteMu sync.Mutex
+var errorBeforeDeleteHooks []ErrorHook
+var errorAfterDeleteMu sync.Mutex
+var errorAfterDeleteHooks []ErrorHook
+
+var errorBeforeUpsertMu sync.Mutex
+var errorBeforeUpsertHooks []ErrorHook
+var errorAfterUpsertMu sync.Mutex
+var errorAfterUpsertHooks []ErrorHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Error) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Error) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Error) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Error) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Error) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Error) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Error) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Error) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Error) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range errorAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddErrorHook registers your hook function for all future operations.
+func AddErrorHook(hookPoint boil.HookPoint, errorHook ErrorHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		errorAfterSelectMu.Lock()
+		errorAfterSelectHooks = append(errorAfterSelectHooks, errorHook)
+		errorAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		errorBeforeInsertMu.Lock()
+		errorBeforeInsertHooks = append(errorBeforeInsertHooks, errorHook)
+		errorBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		errorAfterInsertMu.Lock()
+		errorAfterInsertHooks = append(errorAfterInsertHooks, errorHook)
+		errorAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		errorBeforeUpdateMu.Lock()
+		errorBeforeUpdateHooks = append(errorBeforeUpdateHooks, errorHook)
+		errorBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		errorAfterUpdateMu.Lock()
+		errorAfterUpdateHooks = append(errorAfterUpdateHooks, errorHook)
+		errorAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		errorBeforeDeleteMu.Lock()
+		errorBeforeDeleteHooks = append(errorBeforeDeleteHooks, errorHook)
+		errorBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		errorAfterDeleteMu.Lock()
+		errorAfterDeleteHooks = append(errorAfterDeleteHooks, errorHook)
+		errorAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		errorBeforeUpsertMu.Lock()
+		errorBeforeUpsertHooks = append(errorBeforeUpsertHooks, errorHook)
+		errorBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		errorAfterUpsertMu.Lock()
+		errorAfterUpsertHooks = append(errorAfterUpsertHooks, errorHook)
+		errorAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single error record from the query using the global executor.
+func (q errorQuery) OneG(ctx context.Context) (*Error, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single error record from the query.
+func (q errorQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Error, error) {
+	o := &Error{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for error")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Error records from the query using the global executor.
+func (q errorQuery) AllG(ctx context.Context) (ErrorSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Error records from the query.
+func (q errorQuery) All(ctx context.Context, exec boil.ContextExecutor) (ErrorSlice, error) {
+	var o []*Error
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Error slice")
+	}
+
+	if len(errorAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Error records in the query using the global executor
+func (q errorQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Error records in the query.
+func (q errorQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count error rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q errorQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q errorQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if error exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *Error) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (errorL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeError interface{}, mods queries.Applicator) error {
+	var slice []*Error
+	var object *Error
+
+	if singular {
+		var ok bool
+		object, ok = maybeError.(*Error)
+		if !ok {
+			object = new(Error)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeError)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeError))
+			}
+		}
+	} else {
+		s, ok := maybeError.(*[]*Error)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeError)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeError))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &errorR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &errorR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`gp_joule.configuration`),
+		qm.WhereIn(`gp_joule.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.Errors = append(foreign.R.Errors, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.Errors = append(foreign.R.Errors, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the error to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Errors.
+// Uses the global database handle.
+func (o *Error) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the error to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Errors.
+func (o *Error) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"gp_joule\".\"error\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, errorPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ConfigurationID, o.ErrorID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &errorR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			Errors: ErrorSlice{o},
+		}
+	} else {
+		related.R.Errors = append(related.R.Errors, o)
+	}
+
+	return nil
+}
+
+// Errors retrieves all the records using an executor.
+func Errors(mods ...qm.QueryMod) errorQuery {
+	mods = append(mods, qm.From("\"gp_joule\".\"error\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"gp_joule\".\"error\".*"})
+	}
+
+	return errorQuery{q}
+}
+
+// FindErrorG retrieves a single record by ID.
+func FindErrorG(ctx context.Context, configurationID int64, errorID string, selectCols ...string) (*Error, error) {
+	return FindError(ctx, boil.GetContextDB(), configurationID, errorID, selectCols...)
+}
+
+// FindError retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindError(ctx context.Context, exec boil.ContextExecutor, configurationID int64, errorID string, selectCols ...string) (*Error, error) {
+	errorObj := &Error{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"gp_joule\".\"error\" where \"configuration_id\"=$1 AND \"error_id\"=$2", sel,
+	)
+
+	q := queries.Raw(query, configurationID, errorID)
+
+	err := q.Bind(ctx, exec, errorObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from error")
+	}
+
+	if err = errorObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return errorObj, err
+	}
+
+	return errorObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Error) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Error) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no error provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(errorColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	errorInsertCacheMut.RLock()
+	cache, cached := errorInsertCache[key]
+	errorInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			errorAllColumns,
+			errorColumnsWithDefault,
+			errorColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(errorType, errorMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(errorType, errorMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"gp_joule\".\"error\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"gp_joule\".\"error\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into error")
+	}
+
+	if !cached {
+		errorInsertCacheMut.Lock()
+		errorInsertCache[key] = cache
+		errorInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Error record using the global executor.
+// See Update for more documentation.
+func (o *Error) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Error.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Error) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	errorUpdateCacheMut.RLock()
+	cache, cached := errorUpdateCache[key]
+	errorUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			errorAllColumns,
+			errorPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update error, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"gp_joule\".\"error\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, errorPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(errorType, errorMapping, append(wl, errorPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update error row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for error")
+	}
+
+	if !cached {
+		errorUpdateCacheMut.Lock()
+		errorUpdateCache[key] = cache
+		errorUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q errorQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q errorQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for error")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for error")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o ErrorSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ErrorSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), errorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"gp_joule\".\"error\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, errorPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in error slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all error")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Error) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Error) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no error provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(errorColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	errorUpsertCacheMut.RLock()
+	cache, cached := errorUpsertCache[key]
+	errorUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			errorAllColumns,
+			errorColumnsWithDefault,
+			errorColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			errorAllColumns,
+			errorPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert error, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(errorAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(errorPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert error, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(errorPrimaryKeyColumns))
+			copy(conflict, errorPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"gp_joule\".\"error\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(errorType, errorMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(errorType, errorMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert error")
+	}
+
+	if !cached {
+		errorUpsertCacheMut.Lock()
+		errorUpsertCache[key] = cache
+		errorUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Error record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Error) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Error record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Error) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Error provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), errorPrimaryKeyMapping)
+	sql := "DELETE FROM \"gp_joule\".\"error\" WHERE \"configuration_id\"=$1 AND \"error_id\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from error")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for error")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q errorQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q errorQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no errorQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from error")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for error")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o ErrorSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ErrorSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(errorBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), errorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"gp_joule\".\"error\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, errorPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from error slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for error")
+	}
+
+	if len(errorAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Error) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Error provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Error) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindError(ctx, exec, o.ConfigurationID, o.ErrorID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ErrorSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty ErrorSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ErrorSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ErrorSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), errorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"gp_joule\".\"error\".* FROM \"gp_joule\".\"error\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, errorPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in ErrorSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ErrorExistsG checks if the Error row exists.
+func ErrorExistsG(ctx context.Context, configurationID int64, errorID string) (bool, error) {
+	return ErrorExists(ctx, boil.GetContextDB(), configurationID, errorID)
+}
+
+// ErrorExists checks if the Error row exists.
+func ErrorExists(ctx context.Context, exec boil.ContextExecutor, configurationID int64, errorID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"gp_joule\".\"error\" where \"configuration_id\"=$1 AND \"error_id\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, configurationID, errorID)
+	}
+	row := exec.QueryRowContext(ctx, sql, configurationID, errorID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if error exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Error row exists.
+func (o *Error) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ErrorExists(ctx, exec, o.ConfigurationID, o.ErrorID)
+}
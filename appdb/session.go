@@ -0,0 +1,1244 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Session is an object representing the database table.
+type Session struct {
+	ConfigurationID int64             `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	SessionID       string            `boil:"session_id" json:"session_id" toml:"session_id" yaml:"session_id"`
+	ConnectorID     string            `boil:"connector_id" json:"connector_id" toml:"connector_id" yaml:"connector_id"`
+	SessionEnd      time.Time         `boil:"session_end" json:"session_end" toml:"session_end" yaml:"session_end"`
+	SentAt          time.Time         `boil:"sent_at" json:"sent_at" toml:"sent_at" yaml:"sent_at"`
+	Delivered       types.StringArray `boil:"delivered" json:"delivered" toml:"delivered" yaml:"delivered"`
+
+	R *sessionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L sessionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SessionColumns = struct {
+	ConfigurationID string
+	SessionID       string
+	ConnectorID     string
+	SessionEnd      string
+	SentAt          string
+	Delivered       string
+}{
+	ConfigurationID: "configuration_id",
+	SessionID:       "session_id",
+	ConnectorID:     "connector_id",
+	SessionEnd:      "session_end",
+	SentAt:          "sent_at",
+	Delivered:       "delivered",
+}
+
+var SessionTableColumns = struct {
+	ConfigurationID string
+	SessionID       string
+	ConnectorID     string
+	SessionEnd      string
+	SentAt          string
+	Delivered       string
+}{
+	ConfigurationID: "session.configuration_id",
+	SessionID:       "session.session_id",
+	ConnectorID:     "session.connector_id",
+	SessionEnd:      "session.session_end",
+	SentAt:          "session.sent_at",
+	Delivered:       "session.delivered",
+}
+
+// Generated where
+
+var SessionWhere = struct {
+	ConfigurationID whereHelperint64
+	SessionID       whereHelperstring
+	ConnectorID     whereHelperstring
+	SessionEnd      whereHelpertime_Time
+	SentAt          whereHelpertime_Time
+	Delivered       whereHelpertypes_StringArray
+}{
+	ConfigurationID: whereHelperint64{field: "\"gp_joule\".\"session\".\"configuration_id\""},
+	SessionID:       whereHelperstring{field: "\"gp_joule\".\"session\".\"session_id\""},
+	ConnectorID:     whereHelperstring{field: "\"gp_joule\".\"session\".\"connector_id\""},
+	SessionEnd:      whereHelpertime_Time{field: "\"gp_joule\".\"session\".\"session_end\""},
+	SentAt:          whereHelpertime_Time{field: "\"gp_joule\".\"session\".\"sent_at\""},
+	Delivered:       whereHelpertypes_StringArray{field: "\"gp_joule\".\"session\".\"delivered\""},
+}
+
+// SessionRels is where relationship names are stored.
+var SessionRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// sessionR is where relationships are stored.
+type sessionR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*sessionR) NewStruct() *sessionR {
+	return &sessionR{}
+}
+
+func (r *sessionR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// sessionL is where Load methods for each relationship are stored.
+type sessionL struct{}
+
+var (
+	sessionAllColumns            = []string{"configuration_id", "session_id", "connector_id", "session_end", "sent_at", "delivered"}
+	sessionColumnsWithoutDefault = []string{"configuration_id", "session_id", "connector_id", "session_end"}
+	sessionColumnsWithDefault    = []string{"sent_at", "delivered"}
+	sessionPrimaryKeyColumns     = []string{"configuration_id", "session_id"}
+	sessionGeneratedColumns      = []string{}
+)
+
+type (
+	// SessionSlice is an alias for a slice of pointers to Session.
+	// This should almost always be used instead of []Session.
+	SessionSlice []*Session
+	// SessionHook is the signature for custom Session hook methods
+	SessionHook func(context.Context, boil.ContextExecutor, *Session) error
+
+	sessionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	sessionType                 = reflect.TypeOf(&Session{})
+	sessionMapping              = queries.MakeStructMapping(sessionType)
+	sessionPrimaryKeyMapping, _ = queries.BindMapping(sessionType, sessionMapping, sessionPrimaryKeyColumns)
+	sessionInsertCacheMut       sync.RWMutex
+	sessionInsertCache          = make(map[string]insertCache)
+	sessionUpdateCacheMut       sync.RWMutex
+	sessionUpdateCache          = make(map[string]updateCache)
+	sessionUpsertCacheMut       sync.RWMutex
+	sessionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var sessionAfterSelectMu sync.Mutex
+var sessionAfterSelectHooks []SessionHook
+
+var sessionBeforeInsertMu sync.Mutex
+var sessionBeforeInsertHooks []SessionHook
+var sessionAfterInsertMu sync.Mutex
+var sessionAfterInsertHooks []SessionHook
+
+var sessionBeforeUpdateMu sync.Mutex
+var sessionBeforeUpdateHooks []SessionHook
+var sessionAfterUpdateMu sync.Mutex
+var sessionAfterUpdateHooks []SessionHook
+
+var sessionBeforeDeleteMu sync.Mutex
+var sessionBeforeDeleteHooks []SessionHook
+var sessionAfterDeleteMu sync.Mutex
+var sessionAfterDeleteHooks []SessionHook
+
+var sessionBeforeUpsertMu sync.Mutex
+var sessionBeforeUpsertHooks []SessionHook
+var sessionAfterUpsertMu sync.Mutex
+var sessionAfterUpsertHooks []SessionHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Session) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Session) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Session) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Session) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Session) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Session) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Session) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Session) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Session) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sessionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSessionHook registers your hook function for all future operations.
+func AddSessionHook(hookPoint boil.HookPoint, sessionHook SessionHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		sessionAfterSelectMu.Lock()
+		sessionAfterSelectHooks = append(sessionAfterSelectHooks, sessionHook)
+		sessionAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		sessionBeforeInsertMu.Lock()
+		sessionBeforeInsertHooks = append(sessionBeforeInsertHooks, sessionHook)
+		sessionBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		sessionAfterInsertMu.Lock()
+		sessionAfterInsertHooks = append(sessionAfterInsertHooks, sessionHook)
+		sessionAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		sessionBeforeUpdateMu.Lock()
+		sessionBeforeUpdateHooks = append(sessionBeforeUpdateHooks, sessionHook)
+		sessionBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		sessionAfterUpdateMu.Lock()
+		sessionAfterUpdateHooks = append(sessionAfterUpdateHooks, sessionHook)
+		sessionAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		sessionBeforeDeleteMu.Lock()
+		sessionBeforeDeleteHooks = append(sessionBeforeDeleteHooks, sessionHook)
+		sessionBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		sessionAfterDeleteMu.Lock()
+		sessionAfterDeleteHooks = append(sessionAfterDeleteHooks, sessionHook)
+		sessionAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		sessionBeforeUpsertMu.Lock()
+		sessionBeforeUpsertHooks = append(sessionBeforeUpsertHooks, sessionHook)
+		sessionBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		sessionAfterUpsertMu.Lock()
+		sessionAfterUpsertHooks = append(sessionAfterUpsertHooks, sessionHook)
+		sessionAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single session record from the query using the global executor.
+func (q sessionQuery) OneG(ctx context.Context) (*Session, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single session record from the query.
+func (q sessionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Session, error) {
+	o := &Session{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for session")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all Session records from the query using the global executor.
+func (q sessionQuery) AllG(ctx context.Context) (SessionSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all Session records from the query.
+func (q sessionQuery) All(ctx context.Context, exec boil.ContextExecutor) (SessionSlice, error) {
+	var o []*Session
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to Session slice")
+	}
+
+	if len(sessionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all Session records in the query using the global executor
+func (q sessionQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all Session records in the query.
+func (q sessionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count session rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q sessionQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q sessionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if session exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *Session) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (sessionL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeSession interface{}, mods queries.Applicator) error {
+	var slice []*Session
+	var object *Session
+
+	if singular {
+		var ok bool
+		object, ok = maybeSession.(*Session)
+		if !ok {
+			object = new(Session)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeSession)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeSession))
+			}
+		}
+	} else {
+		s, ok := maybeSession.(*[]*Session)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeSession)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeSession))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &sessionR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &sessionR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`gp_joule.configuration`),
+		qm.WhereIn(`gp_joule.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.Sessions = append(foreign.R.Sessions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.Sessions = append(foreign.R.Sessions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the session to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Sessions.
+// Uses the global database handle.
+func (o *Session) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the session to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.Sessions.
+func (o *Session) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"gp_joule\".\"session\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, sessionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ConfigurationID, o.SessionID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &sessionR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			Sessions: SessionSlice{o},
+		}
+	} else {
+		related.R.Sessions = append(related.R.Sessions, o)
+	}
+
+	return nil
+}
+
+// Sessions retrieves all the records using an executor.
+func Sessions(mods ...qm.QueryMod) sessionQuery {
+	mods = append(mods, qm.From("\"gp_joule\".\"session\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"gp_joule\".\"session\".*"})
+	}
+
+	return sessionQuery{q}
+}
+
+// FindSessionG retrieves a single record by ID.
+func FindSessionG(ctx context.Context, configurationID int64, sessionID string, selectCols ...string) (*Session, error) {
+	return FindSession(ctx, boil.GetContextDB(), configurationID, sessionID, selectCols...)
+}
+
+// FindSession retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSession(ctx context.Context, exec boil.ContextExecutor, configurationID int64, sessionID string, selectCols ...string) (*Session, error) {
+	sessionObj := &Session{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"gp_joule\".\"session\" where \"configuration_id\"=$1 AND \"session_id\"=$2", sel,
+	)
+
+	q := queries.Raw(query, configurationID, sessionID)
+
+	err := q.Bind(ctx, exec, sessionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from session")
+	}
+
+	if err = sessionObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return sessionObj, err
+	}
+
+	return sessionObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *Session) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Session) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no session provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(sessionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	sessionInsertCacheMut.RLock()
+	cache, cached := sessionInsertCache[key]
+	sessionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			sessionAllColumns,
+			sessionColumnsWithDefault,
+			sessionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(sessionType, sessionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(sessionType, sessionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"gp_joule\".\"session\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"gp_joule\".\"session\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into session")
+	}
+
+	if !cached {
+		sessionInsertCacheMut.Lock()
+		sessionInsertCache[key] = cache
+		sessionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single Session record using the global executor.
+// See Update for more documentation.
+func (o *Session) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the Session.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Session) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	sessionUpdateCacheMut.RLock()
+	cache, cached := sessionUpdateCache[key]
+	sessionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			sessionAllColumns,
+			sessionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update session, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"gp_joule\".\"session\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, sessionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(sessionType, sessionMapping, append(wl, sessionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update session row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for session")
+	}
+
+	if !cached {
+		sessionUpdateCacheMut.Lock()
+		sessionUpdateCache[key] = cache
+		sessionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q sessionQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q sessionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for session")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for session")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o SessionSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SessionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sessionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"gp_joule\".\"session\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, sessionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in session slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all session")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *Session) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Session) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no session provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(sessionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	sessionUpsertCacheMut.RLock()
+	cache, cached := sessionUpsertCache[key]
+	sessionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			sessionAllColumns,
+			sessionColumnsWithDefault,
+			sessionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			sessionAllColumns,
+			sessionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert session, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(sessionAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(sessionPrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert session, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(sessionPrimaryKeyColumns))
+			copy(conflict, sessionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"gp_joule\".\"session\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(sessionType, sessionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(sessionType, sessionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert session")
+	}
+
+	if !cached {
+		sessionUpsertCacheMut.Lock()
+		sessionUpsertCache[key] = cache
+		sessionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single Session record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *Session) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single Session record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Session) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no Session provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), sessionPrimaryKeyMapping)
+	sql := "DELETE FROM \"gp_joule\".\"session\" WHERE \"configuration_id\"=$1 AND \"session_id\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from session")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for session")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q sessionQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q sessionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no sessionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from session")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for session")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o SessionSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SessionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(sessionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sessionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"gp_joule\".\"session\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, sessionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from session slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for session")
+	}
+
+	if len(sessionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *Session) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no Session provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Session) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindSession(ctx, exec, o.ConfigurationID, o.SessionID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SessionSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty SessionSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SessionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SessionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sessionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"gp_joule\".\"session\".* FROM \"gp_joule\".\"session\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, sessionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in SessionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SessionExistsG checks if the Session row exists.
+func SessionExistsG(ctx context.Context, configurationID int64, sessionID string) (bool, error) {
+	return SessionExists(ctx, boil.GetContextDB(), configurationID, sessionID)
+}
+
+// SessionExists checks if the Session row exists.
+func SessionExists(ctx context.Context, exec boil.ContextExecutor, configurationID int64, sessionID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"gp_joule\".\"session\" where \"configuration_id\"=$1 AND \"session_id\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, configurationID, sessionID)
+	}
+	row := exec.QueryRowContext(ctx, sql, configurationID, sessionID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if session exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Session row exists.
+func (o *Session) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return SessionExists(ctx, exec, o.ConfigurationID, o.SessionID)
+}
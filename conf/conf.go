@@ -23,19 +23,186 @@ import (
 	"fmt"
 	"gp-joule/apiserver"
 	"gp-joule/appdb"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 var ErrBadRequest = errors.New("bad request")
 
+// NameTemplatePlaceholders lists the placeholders usable in the name templates by asset kind.
+var NameTemplatePlaceholders = map[string][]string{
+	"root":         {"config_id"},
+	"cluster":      {"name"},
+	"charge_point": {"name", "name_internal", "id", "ocpp_id", "cluster", "street", "zip", "city", "country"},
+	"connector":    {"index", "evse_id", "connector_id", "plug_type", "charge_point_type", "charge_point"},
+	"session_log":  {"connector", "index", "evse_id", "connector_id", "charge_point"},
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// validateNameTemplates checks that the templates are defined for known asset kinds and only use the
+// placeholders available for the kind.
+func validateNameTemplates(templates map[string]string) error {
+	for kind, template := range templates {
+		placeholders, ok := NameTemplatePlaceholders[kind]
+		if !ok {
+			return fmt.Errorf("%w: unknown asset kind %s in name templates", ErrBadRequest, kind)
+		}
+		for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+			if !slices.Contains(placeholders, match[1]) {
+				return fmt.Errorf("%w: unknown placeholder {{%s}} in name template for %s, available are %v", ErrBadRequest, match[1], kind, placeholders)
+			}
+		}
+		rest := placeholderPattern.ReplaceAllString(template, "")
+		if strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+			return fmt.Errorf("%w: malformed placeholder in name template for %s", ErrBadRequest, kind)
+		}
+	}
+	return nil
+}
+
+// validateProjectMappings checks that each mapping selects clusters by a valid filter and names at least one project.
+func validateProjectMappings(mappings []apiserver.ProjectMapping) error {
+	for i, mapping := range mappings {
+		if len(mapping.Filter) == 0 {
+			return fmt.Errorf("%w: project mapping %d has no filter", ErrBadRequest, i)
+		}
+		if len(mapping.ProjectIDs) == 0 {
+			return fmt.Errorf("%w: project mapping %d has no project IDs", ErrBadRequest, i)
+		}
+		if err := ValidateFilter(mapping.Filter); err != nil {
+			return fmt.Errorf("invalid filter in project mapping %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Operators of filter rules
+const (
+	FilterOperatorRegex = "regex"
+	FilterOperatorEq    = "eq"
+	FilterOperatorGt    = "gt"
+	FilterOperatorGte   = "gte"
+	FilterOperatorLt    = "lt"
+	FilterOperatorLte   = "lte"
+	FilterOperatorIn    = "in"
+)
+
+// ValidateFilter checks that the rules of the filter use known operators with valid operands. An empty regex
+// matches everything and is valid.
+func ValidateFilter(filter [][]apiserver.FilterRule) error {
+	for _, rules := range filter {
+		for _, rule := range rules {
+			switch rule.Operator {
+			case "", FilterOperatorRegex:
+				if _, err := regexp.Compile(rule.Regex); err != nil {
+					return fmt.Errorf("%w: invalid regex %s of parameter %s: %v", ErrBadRequest, rule.Regex, rule.Parameter, err)
+				}
+			case FilterOperatorGt, FilterOperatorGte, FilterOperatorLt, FilterOperatorLte:
+				if _, err := strconv.ParseFloat(rule.Value, 64); err != nil {
+					return fmt.Errorf("%w: value %s of operator %s for parameter %s is not a number", ErrBadRequest, rule.Value, rule.Operator, rule.Parameter)
+				}
+			case FilterOperatorIn:
+				if len(rule.Values) == 0 {
+					return fmt.Errorf("%w: operator %s for parameter %s has no values", ErrBadRequest, rule.Operator, rule.Parameter)
+				}
+			case FilterOperatorEq:
+			default:
+				return fmt.Errorf("%w: unknown filter operator %s for parameter %s", ErrBadRequest, rule.Operator, rule.Parameter)
+			}
+		}
+	}
+	return nil
+}
+
+// Limits of the numeric settings of a configuration
+const (
+	MaxRetriesLimit       = 10
+	RateLimitLimit        = 6000
+	OfflineThresholdLimit = 7 * 24 * 60 * 60
+	SessionOverlapLimit   = 30 * 24 * 60 * 60
+)
+
+// validateLimits checks that the numeric settings are neither negative nor beyond their limits.
+func validateLimits(apiConfig apiserver.Configuration) error {
+	settings := []struct {
+		name  string
+		value *int32
+		limit int32
+	}{
+		{"maxRetries", apiConfig.MaxRetries, MaxRetriesLimit},
+		{"rateLimit", apiConfig.RateLimit, RateLimitLimit},
+		{"offlineThreshold", apiConfig.OfflineThreshold, OfflineThresholdLimit},
+		{"sessionOverlap", apiConfig.SessionOverlap, SessionOverlapLimit},
+	}
+	for _, setting := range settings {
+		if setting.value == nil {
+			continue
+		}
+		if *setting.value < 0 || *setting.value > setting.limit {
+			return fmt.Errorf("%w: %s %d out of range 0 to %d", ErrBadRequest, setting.name, *setting.value, setting.limit)
+		}
+	}
+	return nil
+}
+
+// validateErrorCodes checks that the entries of the error code catalogue are unique and only use known
+// severities and languages.
+func validateErrorCodes(errorCodes []apiserver.ErrorCode) error {
+	codes := make(map[string]bool)
+	for i, errorCode := range errorCodes {
+		if errorCode.Code == "" {
+			return fmt.Errorf("%w: error code %d has no code", ErrBadRequest, i)
+		}
+		if codes[errorCode.Code] {
+			return fmt.Errorf("%w: duplicate error code %s", ErrBadRequest, errorCode.Code)
+		}
+		codes[errorCode.Code] = true
+		if errorCode.Severity != "" && !slices.Contains(ErrorSeverities, errorCode.Severity) {
+			return fmt.Errorf("%w: unknown severity %s of error code %s, available are %v", ErrBadRequest, errorCode.Severity, errorCode.Code, ErrorSeverities)
+		}
+		for language := range errorCode.Translation {
+			if !slices.Contains(ErrorLanguages, language) {
+				return fmt.Errorf("%w: unknown language %s of error code %s, available are %v", ErrBadRequest, language, errorCode.Code, ErrorLanguages)
+			}
+		}
+	}
+	return nil
+}
+
+// Severities of errors deciding the priority of their alarms
+const (
+	ErrorSeverityHigh   = "high"
+	ErrorSeverityMedium = "medium"
+	ErrorSeverityLow    = "low"
+)
+
+// ErrorSeverities lists the severities of errors from the highest to the lowest.
+var ErrorSeverities = []string{ErrorSeverityHigh, ErrorSeverityMedium, ErrorSeverityLow}
+
+// ErrorLanguages lists the languages of the error messages.
+var ErrorLanguages = []string{"de", "en", "fr", "it"}
+
+// Policies for assets that disappeared from GP Joule
+const (
+	RemovalPolicyInactive = "inactive"
+	RemovalPolicyArchive  = "archive"
+	RemovalPolicyDelete   = "delete"
+)
+
 func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
+		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %w", err)
 	}
 	if err := dbConfig.InsertG(ctx, boil.Infer()); err != nil {
 		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
@@ -46,7 +213,7 @@ func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserve
 func UpsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
+		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %w", err)
 	}
 	if err := dbConfig.UpsertG(ctx, true, []string{"id"}, boil.Blacklist("id"), boil.Infer()); err != nil {
 		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
@@ -102,6 +269,73 @@ func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuratio
 	if apiConfig.RequestTimeout != nil {
 		dbConfig.RequestTimeout = *apiConfig.RequestTimeout
 	}
+	if err := validateLimits(apiConfig); err != nil {
+		return appdb.Configuration{}, err
+	}
+	if apiConfig.MaxRetries != nil {
+		dbConfig.MaxRetries = *apiConfig.MaxRetries
+	}
+	if apiConfig.RateLimit != nil {
+		dbConfig.RateLimit = *apiConfig.RateLimit
+	}
+	if apiConfig.OfflineThreshold != nil {
+		dbConfig.OfflineThreshold = *apiConfig.OfflineThreshold
+	}
+	if apiConfig.SessionOverlap != nil {
+		dbConfig.SessionOverlap = *apiConfig.SessionOverlap
+	}
+	if apiConfig.RenameAssets != nil {
+		dbConfig.RenameAssets = *apiConfig.RenameAssets
+	}
+	dbConfig.RemovalPolicy = RemovalPolicyInactive
+	if apiConfig.RemovalPolicy != nil {
+		switch *apiConfig.RemovalPolicy {
+		case RemovalPolicyInactive, RemovalPolicyArchive, RemovalPolicyDelete:
+			dbConfig.RemovalPolicy = *apiConfig.RemovalPolicy
+		default:
+			return appdb.Configuration{}, fmt.Errorf("%w: unknown removal policy %s", ErrBadRequest, *apiConfig.RemovalPolicy)
+		}
+	}
+	if apiConfig.NameTemplates != nil {
+		if err := validateNameTemplates(apiConfig.NameTemplates); err != nil {
+			return appdb.Configuration{}, err
+		}
+		nt, err := json.Marshal(apiConfig.NameTemplates)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling nameTemplates: %v", err)
+		}
+		dbConfig.NameTemplates = null.JSONFrom(nt)
+	}
+	if apiConfig.ProjectMappings != nil {
+		if err := validateProjectMappings(apiConfig.ProjectMappings); err != nil {
+			return appdb.Configuration{}, err
+		}
+		pm, err := json.Marshal(apiConfig.ProjectMappings)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling projectMappings: %v", err)
+		}
+		dbConfig.ProjectMappings = null.JSONFrom(pm)
+	}
+	if apiConfig.ErrorCodes != nil {
+		if err := validateErrorCodes(apiConfig.ErrorCodes); err != nil {
+			return appdb.Configuration{}, err
+		}
+		ec, err := json.Marshal(apiConfig.ErrorCodes)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling errorCodes: %v", err)
+		}
+		dbConfig.ErrorCodes = null.JSONFrom(ec)
+	}
+	dbConfig.ErrorLanguage = "en"
+	if apiConfig.ErrorLanguage != nil {
+		if !slices.Contains(ErrorLanguages, *apiConfig.ErrorLanguage) {
+			return appdb.Configuration{}, fmt.Errorf("%w: unknown error language %s, available are %v", ErrBadRequest, *apiConfig.ErrorLanguage, ErrorLanguages)
+		}
+		dbConfig.ErrorLanguage = *apiConfig.ErrorLanguage
+	}
+	if err := ValidateFilter(apiConfig.AssetFilter); err != nil {
+		return appdb.Configuration{}, err
+	}
 	af, err := json.Marshal(apiConfig.AssetFilter)
 	if err != nil {
 		return appdb.Configuration{}, fmt.Errorf("marshalling assetFilter: %v", err)
@@ -128,6 +362,12 @@ func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.C
 	apiConfig.Enable = dbConfig.Enable.Ptr()
 	apiConfig.RefreshInterval = dbConfig.RefreshInterval
 	apiConfig.RequestTimeout = &dbConfig.RequestTimeout
+	apiConfig.MaxRetries = &dbConfig.MaxRetries
+	apiConfig.RateLimit = &dbConfig.RateLimit
+	apiConfig.OfflineThreshold = &dbConfig.OfflineThreshold
+	apiConfig.SessionOverlap = &dbConfig.SessionOverlap
+	apiConfig.RemovalPolicy = &dbConfig.RemovalPolicy
+	apiConfig.RenameAssets = &dbConfig.RenameAssets
 	if dbConfig.AssetFilter.Valid {
 		var af [][]apiserver.FilterRule
 		if err := json.Unmarshal(dbConfig.AssetFilter.JSON, &af); err != nil {
@@ -135,6 +375,28 @@ func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.C
 		}
 		apiConfig.AssetFilter = af
 	}
+	if dbConfig.NameTemplates.Valid {
+		var nt map[string]string
+		if err := json.Unmarshal(dbConfig.NameTemplates.JSON, &nt); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling nameTemplates: %v", err)
+		}
+		apiConfig.NameTemplates = nt
+	}
+	if dbConfig.ProjectMappings.Valid {
+		var pm []apiserver.ProjectMapping
+		if err := json.Unmarshal(dbConfig.ProjectMappings.JSON, &pm); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling projectMappings: %v", err)
+		}
+		apiConfig.ProjectMappings = pm
+	}
+	if dbConfig.ErrorCodes.Valid {
+		var ec []apiserver.ErrorCode
+		if err := json.Unmarshal(dbConfig.ErrorCodes.JSON, &ec); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling errorCodes: %v", err)
+		}
+		apiConfig.ErrorCodes = ec
+	}
+	apiConfig.ErrorLanguage = &dbConfig.ErrorLanguage
 	apiConfig.Active = dbConfig.Active.Ptr()
 	apiConfig.ProjectIDs = common.Ptr[[]string](dbConfig.ProjectIds)
 	apiConfig.UserId = dbConfig.UserID.Ptr()
@@ -186,7 +448,8 @@ func SetAllConfigsInactive(ctx context.Context) (int64, error) {
 	})
 }
 
-func InsertAsset(ctx context.Context, config *apiserver.Configuration, projId string, globalAssetID string, assetId int32, assetType string, parentProviderId string, providerId string) error {
+// InsertAsset records the asset created in Eliona together with the name it was created with.
+func InsertAsset(ctx context.Context, config *apiserver.Configuration, projId string, globalAssetID string, assetId int32, assetType string, name string, parentProviderId string, providerId string) error {
 	var dbAsset appdb.Asset
 	dbAsset.ConfigurationID = null.Int64FromPtr(config.Id).Int64
 	dbAsset.ProjectID = projId
@@ -195,10 +458,19 @@ func InsertAsset(ctx context.Context, config *apiserver.Configuration, projId st
 	dbAsset.ParentProviderID = parentProviderId
 	dbAsset.ProviderID = providerId
 	dbAsset.AssetType = null.StringFrom(assetType)
+	dbAsset.Name = null.StringFrom(name)
 	return dbAsset.InsertG(ctx, boil.Infer())
 }
 
 func GetAssetId(ctx context.Context, config *apiserver.Configuration, projId string, globalAssetID string) (*int32, error) {
+	dbAsset, err := GetAsset(ctx, config, projId, globalAssetID)
+	if err != nil || dbAsset == nil {
+		return nil, err
+	}
+	return common.Ptr(dbAsset.AssetID.Int32), nil
+}
+
+func GetAsset(ctx context.Context, config *apiserver.Configuration, projId string, globalAssetID string) (*appdb.Asset, error) {
 	dbAsset, err := appdb.Assets(
 		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
 		appdb.AssetWhere.ProjectID.EQ(projId),
@@ -207,7 +479,14 @@ func GetAssetId(ctx context.Context, config *apiserver.Configuration, projId str
 	if err != nil || len(dbAsset) == 0 {
 		return nil, err
 	}
-	return common.Ptr(dbAsset[0].AssetID.Int32), nil
+	return dbAsset[0], nil
+}
+
+func GetAssets(ctx context.Context, config *apiserver.Configuration, projId string) (appdb.AssetSlice, error) {
+	return appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+		appdb.AssetWhere.ProjectID.EQ(projId),
+	).AllG(ctx)
 }
 
 func GetConnectors(ctx context.Context, config *apiserver.Configuration) (appdb.AssetSlice, error) {
@@ -215,22 +494,69 @@ func GetConnectors(ctx context.Context, config *apiserver.Configuration) (appdb.
 		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
 		appdb.AssetWhere.InitVersion.GTE(1),
 		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_connector")),
+		appdb.AssetWhere.RemovedAt.IsNull(),
+	).AllG(ctx)
+}
+
+// GetChargePoints returns the charge points of the configuration in all projects.
+func GetChargePoints(ctx context.Context, config *apiserver.Configuration) (appdb.AssetSlice, error) {
+	return appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+		appdb.AssetWhere.InitVersion.GTE(0),
+		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_charge_point")),
+		appdb.AssetWhere.RemovedAt.IsNull(),
 	).AllG(ctx)
 }
 
+// GetConnectorIndices returns the stored indices of the connectors of the charge point by connector id.
+func GetConnectorIndices(ctx context.Context, config *apiserver.Configuration, chargePointId string) (map[string]int, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_connector")),
+		appdb.AssetWhere.ParentProviderID.EQ(chargePointId),
+		appdb.AssetWhere.ConnectorIndex.IsNotNull(),
+	).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indices := make(map[string]int)
+	for _, dbAsset := range dbAssets {
+		indices[dbAsset.ProviderID] = int(dbAsset.ConnectorIndex.Int32)
+	}
+	return indices, nil
+}
+
+// SetConnectorIndex stores the index of the connector for all its assets without index.
+func SetConnectorIndex(ctx context.Context, config *apiserver.Configuration, connectorId string, index int) error {
+	_, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_connector")),
+		appdb.AssetWhere.ProviderID.EQ(connectorId),
+		appdb.AssetWhere.ConnectorIndex.IsNull(),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.AssetColumns.ConnectorIndex: index,
+	})
+	return err
+}
+
 func GetConnectorsPerProject(ctx context.Context, projectId string) (appdb.AssetSlice, error) {
 	return appdb.Assets(
 		appdb.AssetWhere.ProjectID.EQ(projectId),
 		appdb.AssetWhere.InitVersion.GTE(1),
 		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_connector")),
+		appdb.AssetWhere.RemovedAt.IsNull(),
 	).AllG(ctx)
 }
 
-func GetSessionsLog(ctx context.Context, chargePointId string) (*appdb.Asset, error) {
+// GetSessionsLog returns the session log of the connector in the project, or nil if there is none.
+func GetSessionsLog(ctx context.Context, config *apiserver.Configuration, projectId string, connectorId string) (*appdb.Asset, error) {
 	assets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+		appdb.AssetWhere.ProjectID.EQ(projectId),
 		appdb.AssetWhere.InitVersion.GTE(0),
 		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_session_log")),
-		appdb.AssetWhere.ParentProviderID.EQ(chargePointId),
+		appdb.AssetWhere.ParentProviderID.EQ(connectorId),
+		appdb.AssetWhere.RemovedAt.IsNull(),
 	).AllG(ctx)
 	if err != nil {
 		return nil, err
@@ -240,11 +566,16 @@ func GetSessionsLog(ctx context.Context, chargePointId string) (*appdb.Asset, er
 	}
 	return assets[0], nil
 }
-func GetChargePoint(ctx context.Context, chargePointId string) (*appdb.Asset, error) {
+
+// GetChargePoint returns the charge point in the project, or nil if there is none.
+func GetChargePoint(ctx context.Context, config *apiserver.Configuration, projectId string, chargePointId string) (*appdb.Asset, error) {
 	assets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+		appdb.AssetWhere.ProjectID.EQ(projectId),
 		appdb.AssetWhere.InitVersion.GTE(0),
 		appdb.AssetWhere.AssetType.EQ(null.StringFrom("gp_joule_charge_point")),
 		appdb.AssetWhere.ProviderID.EQ(chargePointId),
+		appdb.AssetWhere.RemovedAt.IsNull(),
 	).AllG(ctx)
 	if err != nil {
 		return nil, err
@@ -272,3 +603,122 @@ func GetConfigForAsset(asset appdb.Asset) (apiserver.Configuration, error) {
 	}
 	return apiConfigFromDbConfig(c)
 }
+
+// GetSession returns the session recorded with the given ID, or nil if it wasn't recorded yet.
+func GetSession(ctx context.Context, config *apiserver.Configuration, sessionId string) (*appdb.Session, error) {
+	dbSession, err := appdb.FindSessionG(ctx, *config.Id, sessionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return dbSession, err
+}
+
+// StoreSession records the session together with the data delivered to Eliona so far.
+func StoreSession(ctx context.Context, dbSession *appdb.Session) error {
+	return dbSession.UpsertG(ctx, true,
+		[]string{appdb.SessionColumns.ConfigurationID, appdb.SessionColumns.SessionID},
+		boil.Whitelist(appdb.SessionColumns.Delivered),
+		boil.Infer())
+}
+
+// SetSessionCursor stores the latest session sent to the connector.
+func SetSessionCursor(ctx context.Context, dbConnectorAsset *appdb.Asset) error {
+	_, err := dbConnectorAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.LatestSessionTS, appdb.AssetColumns.LatestSessionID))
+	return err
+}
+
+// AfterSessionCursor checks if the session is ordered after the latest session sent to the connector. Sessions are
+// ordered by their end and by their ID if they end at the same time. Cursors stored without ID cover all sessions
+// ending at their time.
+func AfterSessionCursor(dbConnectorAsset *appdb.Asset, sessionEnd time.Time, sessionId string) bool {
+	if !sessionEnd.Equal(dbConnectorAsset.LatestSessionTS) || dbConnectorAsset.LatestSessionID == "" {
+		return sessionEnd.After(dbConnectorAsset.LatestSessionTS)
+	}
+	return sessionId > dbConnectorAsset.LatestSessionID
+}
+
+// GetFirstSessionEnd returns the end of the first session recorded for the connector, or nil if no session was
+// recorded yet.
+func GetFirstSessionEnd(ctx context.Context, config *apiserver.Configuration, connectorId string) (*time.Time, error) {
+	dbSession, err := appdb.Sessions(
+		appdb.SessionWhere.ConfigurationID.EQ(*config.Id),
+		appdb.SessionWhere.ConnectorID.EQ(connectorId),
+		qm.OrderBy(appdb.SessionColumns.SessionEnd),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dbSession.SessionEnd, nil
+}
+
+// SetSessionsReadUntil stores the time the sessions of the connectors were read until.
+func SetSessionsReadUntil(ctx context.Context, dbConnectorAssets appdb.AssetSlice, readUntil time.Time) error {
+	var ids []int64
+	for _, dbConnectorAsset := range dbConnectorAssets {
+		ids = append(ids, dbConnectorAsset.ID)
+		dbConnectorAsset.SessionsReadUntil = readUntil
+	}
+	_, err := appdb.Assets(appdb.AssetWhere.ID.IN(ids)).UpdateAllG(ctx, appdb.M{
+		appdb.AssetColumns.SessionsReadUntil: readUntil,
+	})
+	return err
+}
+
+// GetErrors returns the errors recorded with the given IDs by their ID.
+func GetErrors(ctx context.Context, config *apiserver.Configuration, errorIds []string) (map[string]*appdb.Error, error) {
+	dbErrors, err := appdb.Errors(
+		appdb.ErrorWhere.ConfigurationID.EQ(*config.Id),
+		appdb.ErrorWhere.ErrorID.IN(errorIds),
+	).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[string]*appdb.Error)
+	for _, dbError := range dbErrors {
+		byId[dbError.ErrorID] = dbError
+	}
+	return byId, nil
+}
+
+// GetOpenErrors returns the errors still open for the assets with the given provider IDs, ordered by their occurrence.
+func GetOpenErrors(ctx context.Context, config *apiserver.Configuration, providerIds []string) (appdb.ErrorSlice, error) {
+	return appdb.Errors(
+		appdb.ErrorWhere.ConfigurationID.EQ(*config.Id),
+		appdb.ErrorWhere.ProviderID.IN(providerIds),
+		appdb.ErrorWhere.ResolvedAt.IsNull(),
+		qm.OrderBy(appdb.ErrorColumns.OccurredAt),
+	).AllG(ctx)
+}
+
+// StoreErrors records the errors with their current state and stores the error cursors of the assets in one
+// transaction. The cursors are the latest error of the assets and the time their errors were read until.
+func StoreErrors(ctx context.Context, dbErrors appdb.ErrorSlice, dbAssets appdb.AssetSlice) error {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, dbError := range dbErrors {
+		err := dbError.Upsert(ctx, tx, true,
+			[]string{appdb.ErrorColumns.ConfigurationID, appdb.ErrorColumns.ErrorID},
+			boil.Whitelist(appdb.ErrorColumns.ResolvedAt),
+			boil.Infer())
+		if err != nil {
+			return fmt.Errorf("upserting error %s: %v", dbError.ErrorID, err)
+		}
+	}
+	for _, dbAsset := range dbAssets {
+		if _, err := dbAsset.Update(ctx, tx, boil.Whitelist(appdb.AssetColumns.LatestErrorTS, appdb.AssetColumns.ErrorsReadUntil)); err != nil {
+			return fmt.Errorf("updating latest error: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing errors: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package conf
+
+import (
+	"errors"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestValidateNameTemplates(t *testing.T) {
+	valid := []map[string]string{
+		nil,
+		{"charge_point": "{{cluster}} / {{name_internal}}"},
+		{"connector": "{{evse_id}}", "session_log": "Sessions {{connector}}", "root": "GP Joule {{config_id}}"},
+		{"cluster": "Site"},
+	}
+	for _, templates := range valid {
+		if err := validateNameTemplates(templates); err != nil {
+			t.Errorf("%v: unexpected error: %v", templates, err)
+		}
+	}
+
+	invalid := []map[string]string{
+		{"station": "{{name}}"},
+		{"cluster": "{{name_internal}}"},
+		{"connector": "{{evse_id}"},
+		{"connector": "{{ {{evse_id}} }}"},
+	}
+	for _, templates := range invalid {
+		if err := validateNameTemplates(templates); !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%v: expected bad request, got %v", templates, err)
+		}
+	}
+}
+
+func TestValidateProjectMappings(t *testing.T) {
+	siteA := [][]apiserver.FilterRule{{{Parameter: "name", Regex: "^Parking A$"}}}
+	valid := [][]apiserver.ProjectMapping{
+		nil,
+		{{Filter: siteA, ProjectIDs: []string{"42"}}},
+	}
+	for _, mappings := range valid {
+		if err := validateProjectMappings(mappings); err != nil {
+			t.Errorf("%v: unexpected error: %v", mappings, err)
+		}
+	}
+
+	invalid := [][]apiserver.ProjectMapping{
+		{{Filter: siteA}},
+		{{ProjectIDs: []string{"42"}}},
+		{{Filter: [][]apiserver.FilterRule{{{Parameter: "name", Regex: "(Parking"}}}, ProjectIDs: []string{"42"}}},
+	}
+	for _, mappings := range invalid {
+		if err := validateProjectMappings(mappings); !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%v: expected bad request, got %v", mappings, err)
+		}
+	}
+}
+
+func TestValidateFilter(t *testing.T) {
+	valid := [][][]apiserver.FilterRule{
+		nil,
+		{{{Parameter: "name", Regex: ""}}},
+		{{{Parameter: "name", Regex: "^Parking A$"}, {Parameter: "max_power", Operator: "gte", Value: "22000"}}},
+		{{{Parameter: "plug_type", Operator: "in", Values: []string{"CCS", "Type2"}, Negate: true}}},
+		{{{Parameter: "city", Operator: "eq", Value: "Berlin"}}},
+	}
+	for _, filter := range valid {
+		if err := ValidateFilter(filter); err != nil {
+			t.Errorf("%v: unexpected error: %v", filter, err)
+		}
+	}
+
+	invalid := [][][]apiserver.FilterRule{
+		{{{Parameter: "name", Regex: "(Parking"}}},
+		{{{Parameter: "max_power", Operator: "gt", Value: "much"}}},
+		{{{Parameter: "plug_type", Operator: "in"}}},
+		{{{Parameter: "name", Operator: "like", Value: "Parking"}}},
+	}
+	for _, filter := range invalid {
+		if err := ValidateFilter(filter); !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%v: expected bad request, got %v", filter, err)
+		}
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	valid := []apiserver.Configuration{
+		{},
+		{MaxRetries: common.Ptr[int32](3), RateLimit: common.Ptr[int32](0), OfflineThreshold: common.Ptr[int32](900), SessionOverlap: common.Ptr[int32](86400)},
+		{MaxRetries: common.Ptr[int32](MaxRetriesLimit), SessionOverlap: common.Ptr[int32](SessionOverlapLimit)},
+	}
+	for _, config := range valid {
+		if err := validateLimits(config); err != nil {
+			t.Errorf("%+v: unexpected error: %v", config, err)
+		}
+	}
+
+	invalid := []apiserver.Configuration{
+		{MaxRetries: common.Ptr[int32](-1)},
+		{MaxRetries: common.Ptr[int32](1000)},
+		{RateLimit: common.Ptr[int32](-5)},
+		{OfflineThreshold: common.Ptr[int32](-900)},
+		{SessionOverlap: common.Ptr[int32](SessionOverlapLimit + 1)},
+	}
+	for _, config := range invalid {
+		if err := validateLimits(config); !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%+v: expected bad request, got %v", config, err)
+		}
+	}
+}
+
+func TestValidateErrorCodes(t *testing.T) {
+	valid := [][]apiserver.ErrorCode{
+		nil,
+		{{Code: "GroundFailure", Severity: ErrorSeverityLow}},
+		{{Code: "0x1F", Translation: map[string]string{"de": "Backend nicht erreichbar", "en": "Backend unreachable"}}, {Code: "OtherError"}},
+	}
+	for _, errorCodes := range valid {
+		if err := validateErrorCodes(errorCodes); err != nil {
+			t.Errorf("%v: unexpected error: %v", errorCodes, err)
+		}
+	}
+
+	invalid := [][]apiserver.ErrorCode{
+		{{Severity: ErrorSeverityHigh}},
+		{{Code: "GroundFailure", Severity: "critical"}},
+		{{Code: "GroundFailure", Translation: map[string]string{"es": "Fallo a tierra"}}},
+		{{Code: "GroundFailure"}, {Code: "GroundFailure", Severity: ErrorSeverityLow}},
+	}
+	for _, errorCodes := range invalid {
+		if err := validateErrorCodes(errorCodes); !errors.Is(err, ErrBadRequest) {
+			t.Errorf("%v: expected bad request, got %v", errorCodes, err)
+		}
+	}
+}
+
+func TestAfterSessionCursor(t *testing.T) {
+	cursor := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	dbConnectorAsset := &appdb.Asset{LatestSessionTS: cursor, LatestSessionID: "s-2"}
+	tests := []struct {
+		end      time.Time
+		id       string
+		expected bool
+	}{
+		{cursor.Add(time.Second), "s-1", true},
+		{cursor.Add(-time.Second), "s-3", false},
+		{cursor, "s-3", true},
+		{cursor, "s-2", false},
+		{cursor, "s-1", false},
+	}
+	for _, test := range tests {
+		if after := AfterSessionCursor(dbConnectorAsset, test.end, test.id); after != test.expected {
+			t.Errorf("%s at %v: expected %t, got %t", test.id, test.end, test.expected, after)
+		}
+	}
+
+	// cursors stored without ID cover all sessions ending at their time
+	dbConnectorAsset.LatestSessionID = ""
+	if AfterSessionCursor(dbConnectorAsset, cursor, "s-3") {
+		t.Errorf("session at cursor without ID is after the cursor")
+	}
+}
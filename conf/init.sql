@@ -23,6 +23,16 @@ create table if not exists gp_joule.configuration
 	api_key              text not null,
 	refresh_interval     integer not null default 60,
 	request_timeout      integer not null default 120,
+	max_retries          integer not null default 3,
+	rate_limit           integer not null default 0,
+	offline_threshold    integer not null default 900,
+	session_overlap      integer not null default 86400,
+	removal_policy       text not null default 'inactive',
+	name_templates       json,
+	rename_assets        boolean not null default false,
+	project_mappings     json,
+	error_codes          json,
+	error_language       text not null default 'en',
 	asset_filter         json,
 	active               boolean default false,
 	enable               boolean default false,
@@ -42,8 +52,46 @@ create table if not exists gp_joule.asset
 	asset_type          text,
 	init_version        integer   not null default 0,
 	latest_session_ts   timestamp with time zone not null default '1900-01-01 00:00:00',
-	latest_error_ts     timestamp with time zone not null default '1900-01-01 00:00:00'
+	latest_session_id   text      not null default '',
+	latest_error_ts     timestamp with time zone not null default '1900-01-01 00:00:00',
+	latitude            double precision,
+	longitude           double precision,
+	last_seen           timestamp with time zone not null default now(),
+	removed_at          timestamp with time zone,
+	connector_index     integer,
+	name                text,
+	sessions_read_until timestamp with time zone not null default '1900-01-01 00:00:00',
+	errors_read_until   timestamp with time zone not null default '1900-01-01 00:00:00'
 );
 
+create table if not exists gp_joule.session
+(
+	configuration_id    bigint    not null references gp_joule.configuration(id) ON DELETE CASCADE,
+	session_id          text      not null,
+	connector_id        text      not null,
+	session_end         timestamp with time zone not null,
+	sent_at             timestamp with time zone not null default now(),
+	delivered           text[]    not null default '{}',
+	primary key (configuration_id, session_id)
+);
+
+create index if not exists session_connector_idx on gp_joule.session (configuration_id, connector_id, session_end);
+
+create table if not exists gp_joule.error
+(
+	configuration_id    bigint    not null references gp_joule.configuration(id) ON DELETE CASCADE,
+	error_id            text      not null,
+	charge_point_id     text      not null,
+	provider_id         text      not null,
+	error_code          text      not null default '',
+	error_info          text      not null default '',
+	vendor_code         text      not null default '',
+	occurred_at         timestamp with time zone not null,
+	resolved_at         timestamp with time zone,
+	primary key (configuration_id, error_id)
+);
+
+create index if not exists error_open_idx on gp_joule.error (configuration_id, provider_id) where resolved_at is null;
+
 -- Makes the new objects available for all other init steps
 commit;
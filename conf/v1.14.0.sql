@@ -0,0 +1,30 @@
+--  This file is part of the eliona project.
+--  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+create table if not exists gp_joule.error
+(
+	configuration_id    bigint    not null references gp_joule.configuration(id) ON DELETE CASCADE,
+	error_id            text      not null,
+	charge_point_id     text      not null,
+	provider_id         text      not null,
+	error_code          text      not null default '',
+	error_info          text      not null default '',
+	vendor_code         text      not null default '',
+	occurred_at         timestamp with time zone not null,
+	resolved_at         timestamp with time zone,
+	primary key (configuration_id, error_id)
+);
+
+create index if not exists error_open_idx on gp_joule.error (configuration_id, provider_id) where resolved_at is null;
@@ -0,0 +1,40 @@
+--  This file is part of the eliona project.
+--  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+alter table gp_joule.session add column if not exists delivered text[] not null default '{}';
+
+-- sessions recorded before were delivered to the session logs and charge points of their connector
+update gp_joule.session session set delivered = array(
+	select session_log.asset_id || '/' || subtype
+	from gp_joule.asset session_log, unnest(array['input', 'info']) subtype
+	where session_log.configuration_id = session.configuration_id
+	  and session_log.asset_type = 'gp_joule_session_log'
+	  and session_log.parent_provider_id = session.connector_id
+	  and session_log.asset_id is not null
+	union
+	select charge_point.asset_id || '/input'
+	from gp_joule.asset connector
+	join gp_joule.asset charge_point on charge_point.configuration_id = connector.configuration_id
+		and charge_point.asset_type = 'gp_joule_charge_point'
+		and charge_point.provider_id = connector.parent_provider_id
+	where connector.configuration_id = session.configuration_id
+	  and connector.asset_type = 'gp_joule_connector'
+	  and connector.provider_id = session.connector_id
+	  and charge_point.asset_id is not null
+)
+where session.delivered = '{}';
+
+alter table gp_joule.asset add column if not exists sessions_read_until timestamp with time zone not null default '1900-01-01 00:00:00';
+alter table gp_joule.asset add column if not exists errors_read_until timestamp with time zone not null default '1900-01-01 00:00:00';
@@ -22,17 +22,22 @@ import (
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
 	"github.com/eliona-smart-building-assistant/go-eliona/client"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/db"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"gp-joule/apiserver"
 	"gp-joule/appdb"
+	"gp-joule/conf"
+	"net/http"
+	"slices"
 )
 
 // InitAssets initializes the assets created before. This contains creation of pipeline aggregation and rules for alarms
 func InitAssets(config *apiserver.Configuration) error {
 	dbAssets, err := appdb.Assets(
 		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
-		appdb.AssetWhere.InitVersion.LTE(1),
+		appdb.AssetWhere.InitVersion.LTE(4),
 	).AllG(context.Background())
 	if err != nil {
 		return err
@@ -62,6 +67,39 @@ func initAsset(dbAsset *appdb.Asset) error {
 		}
 	}
 	if dbAsset.InitVersion <= 1 {
+		err := initAssetV2(dbAsset)
+		if err != nil {
+			return err
+		}
+		dbAsset.InitVersion = 2
+		_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.InitVersion))
+		if err != nil {
+			return err
+		}
+	}
+	if dbAsset.InitVersion <= 2 {
+		err := initAssetV3(dbAsset)
+		if err != nil {
+			return err
+		}
+		dbAsset.InitVersion = 3
+		_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.InitVersion))
+		if err != nil {
+			return err
+		}
+	}
+	if dbAsset.InitVersion <= 3 {
+		err := initAssetV4(dbAsset)
+		if err != nil {
+			return err
+		}
+		dbAsset.InitVersion = 4
+		_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.InitVersion))
+		if err != nil {
+			return err
+		}
+	}
+	if dbAsset.InitVersion <= 4 {
 		// Place for init during a patch of new app version
 	}
 	return nil
@@ -82,20 +120,160 @@ func initAssetV1(dbAsset *appdb.Asset) error {
 
 		log.Debug("eliona", "Init version 1 of asset %d", dbAsset.AssetID.Int32)
 
+		if err := postErrorAlarmRules(dbAsset, connectorErrorMessage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateAssetLocation sets the geo-coordinates of the asset in Eliona.
+func UpdateAssetLocation(assetId int32, latitude float64, longitude float64) error {
+	return updateAsset(assetId, func(asset *api.Asset) {
+		asset.Latitude = *api.NewNullableFloat64(&latitude)
+		asset.Longitude = *api.NewNullableFloat64(&longitude)
+	})
+}
+
+// RemovedTag marks assets that disappeared from GP Joule.
+const RemovedTag = "removed"
+
+// MarkAssetRemoved tags the asset as removed. If archiveAssetId is given, the asset is moved below the archive.
+func MarkAssetRemoved(assetId int32, archiveAssetId *int32) error {
+	return updateAsset(assetId, func(asset *api.Asset) {
+		if !slices.Contains(asset.Tags, RemovedTag) {
+			asset.Tags = append(asset.Tags, RemovedTag)
+		}
+		if archiveAssetId != nil {
+			asset.ParentLocationalAssetId = *api.NewNullableInt32(archiveAssetId)
+		}
+	})
+}
+
+// RestoreAsset removes the removed tag from the asset. If the asset is below the archive, it is moved back below
+// the parent.
+func RestoreAsset(assetId int32, archiveAssetId *int32, parentAssetId *int32) error {
+	return updateAsset(assetId, func(asset *api.Asset) {
+		asset.Tags = slices.DeleteFunc(asset.Tags, func(tag string) bool {
+			return tag == RemovedTag
+		})
+		archived := archiveAssetId != nil && asset.ParentLocationalAssetId.Get() != nil && *asset.ParentLocationalAssetId.Get() == *archiveAssetId
+		if archived && parentAssetId != nil {
+			asset.ParentLocationalAssetId = *api.NewNullableInt32(parentAssetId)
+		}
+	})
+}
+
+// RecordAssetNames records the name of the assets recorded without name as they are named in Eliona, so the names
+// of the assets created before names were recorded don't count as changed by the name templates.
+func RecordAssetNames(connection db.Connection) error {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.Name.IsNull(),
+		appdb.AssetWhere.AssetID.IsNotNull(),
+	).AllG(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, dbAsset := range dbAssets {
+		asset, res, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), dbAsset.AssetID.Int32).Execute()
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error getting asset %d: %w", dbAsset.AssetID.Int32, err)
+		}
+		dbAsset.Name = null.StringFromPtr(asset.Name.Get())
+		if _, err := dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.Name)); err != nil {
+			return fmt.Errorf("error recording name of asset %d: %w", dbAsset.AssetID.Int32, err)
+		}
+	}
+	return nil
+}
+
+// RenameAsset sets the name of the asset in Eliona.
+func RenameAsset(assetId int32, name string) error {
+	return updateAsset(assetId, func(asset *api.Asset) {
+		asset.Name = *api.NewNullableString(&name)
+	})
+}
+
+// MoveAsset moves the asset below the parent asset.
+func MoveAsset(assetId int32, parentAssetId int32) error {
+	return updateAsset(assetId, func(asset *api.Asset) {
+		asset.ParentLocationalAssetId = *api.NewNullableInt32(&parentAssetId)
+	})
+}
+
+// ClaimAssetGAI changes the GAI of the asset from legacyGAI to gai. It returns false if the asset doesn't exist
+// anymore or has another GAI, e.g. because another configuration already claimed it.
+func ClaimAssetGAI(assetId int32, legacyGAI string, gai string) (bool, error) {
+	exists, err := asset.ExistAsset(assetId)
+	if err != nil || !exists {
+		return false, err
+	}
+	claimed := false
+	err = updateAsset(assetId, func(asset *api.Asset) {
+		if asset.GlobalAssetIdentifier == legacyGAI {
+			asset.GlobalAssetIdentifier = gai
+			claimed = true
+		}
+	})
+	return claimed, err
+}
+
+// DeleteAsset deletes the asset in Eliona.
+func DeleteAsset(assetId int32) error {
+	_, err := client.NewClient().AssetsAPI.DeleteAssetById(client.AuthenticationContext(), assetId).Execute()
+	if err != nil {
+		return fmt.Errorf("error deleting asset %d: %w", assetId, err)
+	}
+	return nil
+}
+
+// updateAsset reads the asset from Eliona, applies the update and writes it back.
+func updateAsset(assetId int32, update func(asset *api.Asset)) error {
+	asset, _, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), assetId).Execute()
+	if err != nil {
+		return fmt.Errorf("error getting asset %d: %w", assetId, err)
+	}
+	update(asset)
+	_, _, err = client.NewClient().AssetsAPI.PutAssetById(client.AuthenticationContext(), assetId).Asset(*asset).Execute()
+	if err != nil {
+		return fmt.Errorf("error updating asset %d: %w", assetId, err)
+	}
+	return nil
+}
+
+func initAssetV2(dbAsset *appdb.Asset) error {
+
+	// check if asset still exists in Eliona
+	exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	if dbAsset.AssetType.String == "gp_joule_charge_point" {
+
+		log.Debug("eliona", "Init version 2 of asset %d", dbAsset.AssetID.Int32)
+
 		_, _, err := client.NewClient().AlarmRulesAPI.PostAlarmRule(client.AuthenticationContext()).AlarmRule(api.AlarmRule{
 			AssetId:             dbAsset.AssetID.Int32,
 			Subtype:             "status",
-			Attribute:           "error",
+			Attribute:           "offline",
 			Enable:              common.Ptr(true),
 			Priority:            2,
 			RequiresAcknowledge: common.Ptr(false),
 			High:                *api.NewNullableFloat64(common.Ptr(1.0)),
 			Message: map[string]interface{}{
 				"come": map[string]interface{}{
-					"de": "{{asset.name}} ({{alarm.val}})",
-					"en": "{{asset.name}} ({{alarm.val}})",
-					"fr": "{{asset.name}} ({{alarm.val}})",
-					"it": "{{asset.name}} ({{alarm.val}})",
+					"de": "{{asset.name}} ist offline",
+					"en": "{{asset.name}} is offline",
+					"fr": "{{asset.name}} est hors ligne",
+					"it": "{{asset.name}} è offline",
 				},
 			},
 			Subject:  api.NullableString{},
@@ -112,6 +290,123 @@ func initAssetV1(dbAsset *appdb.Asset) error {
 	return nil
 }
 
+// initAssetV3 adds the alarm rules for errors of the whole charge point, which are not assigned to a connector.
+func initAssetV3(dbAsset *appdb.Asset) error {
+
+	// check if asset still exists in Eliona
+	exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	if dbAsset.AssetType.String == "gp_joule_charge_point" {
+
+		log.Debug("eliona", "Init version 3 of asset %d", dbAsset.AssetID.Int32)
+
+		if err := postErrorAlarmRules(dbAsset, chargePointErrorMessage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initAssetV4 replaces the single alarm rule for errors of assets initialized before by the alarm rules per
+// error severity. Assets initialized since already have the rules per severity.
+func initAssetV4(dbAsset *appdb.Asset) error {
+
+	// check if asset still exists in Eliona
+	exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	message, ok := map[string]map[string]interface{}{
+		"gp_joule_connector":    connectorErrorMessage,
+		"gp_joule_charge_point": chargePointErrorMessage,
+	}[dbAsset.AssetType.String]
+	if !ok {
+		return nil
+	}
+
+	log.Debug("eliona", "Init version 4 of asset %d", dbAsset.AssetID.Int32)
+
+	rules, _, err := client.NewClient().AlarmRulesAPI.GetAlarmRules(client.AuthenticationContext()).AssetId(dbAsset.AssetID.Int32).Execute()
+	if err != nil {
+		return fmt.Errorf("error getting alarm rules for asset %d: %w", dbAsset.AssetID.Int32, err)
+	}
+	replaced := false
+	for _, rule := range rules {
+		if rule.Attribute != "error" || rule.Id.Get() == nil {
+			continue
+		}
+		_, err := client.NewClient().AlarmRulesAPI.DeleteAlarmRuleById(client.AuthenticationContext(), *rule.Id.Get()).Execute()
+		if err != nil {
+			return fmt.Errorf("error deleting alarm rule %d for asset %d: %w", *rule.Id.Get(), dbAsset.AssetID.Int32, err)
+		}
+		replaced = true
+	}
+	if replaced {
+		return postErrorAlarmRules(dbAsset, message)
+	}
+	return nil
+}
+
+// errorAlarmPriorities maps the error severities to the priority of their alarms.
+var errorAlarmPriorities = map[string]api.AlarmPriority{
+	conf.ErrorSeverityHigh:   api.ALARM_PRIORITY_HEIGHT,
+	conf.ErrorSeverityMedium: api.ALARM_PRIORITY_MEDIUM,
+	conf.ErrorSeverityLow:    api.ALARM_PRIORITY_LOW,
+}
+
+var connectorErrorMessage = map[string]interface{}{
+	"de": "{{asset.name}} ({{alarm.val}})",
+	"en": "{{asset.name}} ({{alarm.val}})",
+	"fr": "{{asset.name}} ({{alarm.val}})",
+	"it": "{{asset.name}} ({{alarm.val}})",
+}
+
+var chargePointErrorMessage = map[string]interface{}{
+	"de": "{{asset.name}} hat eine Störung ({{alarm.val}})",
+	"en": "{{asset.name}} has a fault ({{alarm.val}})",
+	"fr": "{{asset.name}} a un défaut ({{alarm.val}})",
+	"it": "{{asset.name}} ha un guasto ({{alarm.val}})",
+}
+
+// postErrorAlarmRules adds an alarm rule for the errors open of each severity. The alarm has the priority of the
+// severity, which is looked up in the error code catalogue.
+func postErrorAlarmRules(dbAsset *appdb.Asset, message map[string]interface{}) error {
+	for _, severity := range conf.ErrorSeverities {
+		_, _, err := client.NewClient().AlarmRulesAPI.PostAlarmRule(client.AuthenticationContext()).AlarmRule(api.AlarmRule{
+			AssetId:             dbAsset.AssetID.Int32,
+			Subtype:             "status",
+			Attribute:           "error_" + severity,
+			Enable:              common.Ptr(true),
+			Priority:            errorAlarmPriorities[severity],
+			RequiresAcknowledge: common.Ptr(false),
+			High:                *api.NewNullableFloat64(common.Ptr(1.0)),
+			Message: map[string]interface{}{
+				"come": message,
+			},
+			Subject:  api.NullableString{},
+			Urldoc:   api.NullableString{},
+			NotifyOn: *api.NewNullableString(common.Ptr("R")),
+			DontMask: *api.NewNullableBool(common.Ptr(false)),
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("error during send alarm rule for asset %d: %w", dbAsset.AssetID.Int32, err)
+		}
+		log.Debug("eliona", "Added alarm rule for %s errors of asset %d", severity, dbAsset.AssetID.Int32)
+	}
+	return nil
+}
+
 func NotifyUser(userId *string, projectId string, translation *api.Translation) error {
 	if userId != nil {
 		_, _, err := client.NewClient().CommunicationAPI.
@@ -48,15 +48,21 @@ func GpJouleDashboard(projectId string) (api.Dashboard, error) {
 
 		if exists {
 
+			config, err := conf.GetConfigForAsset(*dbConnectorAsset)
+			if err != nil {
+				log.Error("eliona", "Error getting configuration: %v", err)
+				return dashboard, err
+			}
+
 			// Get sessions asset for this
-			dbSessionsLogAsset, err := conf.GetSessionsLog(context.Background(), dbConnectorAsset.ProviderID)
+			dbSessionsLogAsset, err := conf.GetSessionsLog(context.Background(), &config, projectId, dbConnectorAsset.ProviderID)
 			if err != nil {
 				log.Error("eliona", "Error getting sessions log : %v", err)
 				return dashboard, err
 			}
 
 			// Get charge point asset for this
-			dbChargePointAsset, err := conf.GetChargePoint(context.Background(), dbConnectorAsset.ParentProviderID)
+			dbChargePointAsset, err := conf.GetChargePoint(context.Background(), &config, projectId, dbConnectorAsset.ParentProviderID)
 			if err != nil {
 				log.Error("eliona", "Error getting sessions log : %v", err)
 				return dashboard, err
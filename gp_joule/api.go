@@ -20,7 +20,6 @@ import (
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"gp-joule/apiserver"
-	"gp-joule/appdb"
 	"gp-joule/model"
 	"net/http"
 	"sort"
@@ -28,79 +27,142 @@ import (
 	"time"
 )
 
-func GetClusters(config *apiserver.Configuration) ([]*model.Cluster, error) {
+// Client provides access to the data of the GP Joule API.
+type Client interface {
 
-	// create request
-	fullUrl := config.RootUrl + "/clusters"
-	request, err := request(config, fullUrl)
-	if err != nil {
-		return nil, err
+	// GetClusters reads all clusters including their charge points and connectors.
+	GetClusters() ([]*model.Cluster, error)
+
+	// GetCompletedSessions reads all completed sessions of all connectors of the charge point since from. The sessions
+	// are passed to handle window by window, sorted ascending within each window. Handle is called for empty windows
+	// as well, together with the end of the window read, so callers can continue from there on the next read.
+	GetCompletedSessions(chargePointId string, from time.Time, handle func(completedSessions []*model.ChargingSession, readUntil time.Time) error) error
+
+	// GetErrorNotifications reads all error notifications of the charge point and its connectors occurred since from. The
+	// notifications are passed to handle window by window, sorted ascending within each window. Handle is called for
+	// empty windows as well, together with the end of the window read.
+	GetErrorNotifications(chargePointId string, from time.Time, handle func(notifications []*model.ErrorNotification, readUntil time.Time) error) error
+}
+
+// apiClient is the Client reading from the GP Joule API defined by a configuration.
+type apiClient struct {
+	config *apiserver.Configuration
+}
+
+// NewClient creates a client for the GP Joule API defined by the configuration.
+func NewClient(config *apiserver.Configuration) Client {
+	return &apiClient{config: config}
+}
+
+// previewTimeout is the longest time in seconds a request of a preview client may take.
+const previewTimeout int32 = 10
+
+// NewPreviewClient creates a client for interactive requests to the GP Joule API defined by the configuration,
+// e.g. for the filter preview. Requests aren't retried and time out after previewTimeout at the latest, so the user
+// isn't kept waiting. They don't share the rate limiter of the configuration, so a preview neither waits for the
+// synchronization nor delays it.
+func NewPreviewClient(config *apiserver.Configuration) Client {
+	preview := *config
+	preview.Id = nil
+	preview.MaxRetries = nil
+	preview.RateLimit = nil
+	timeout := previewTimeout
+	if config.RequestTimeout != nil && *config.RequestTimeout > 0 {
+		timeout = min(*config.RequestTimeout, previewTimeout)
 	}
+	preview.RequestTimeout = &timeout
+	return &apiClient{config: &preview}
+}
+
+func (c *apiClient) GetClusters() ([]*model.Cluster, error) {
 
 	// read clusters
-	log.Trace("gp-joule", "Reading URL %s", fullUrl)
-	clusters, statusCode, err := utilshttp.ReadWithStatusCode[[]*model.Cluster](request, time.Duration(*config.RequestTimeout)*time.Second, true)
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
+	fullUrl := c.config.RootUrl + "/clusters"
+	clusters, err := read[[]*model.Cluster](c.config, fullUrl)
+	if err != nil {
+		return nil, err
 	}
 
 	return clusters, nil
 }
 
-func GetCompletedSessions(config *apiserver.Configuration, dbConnectorAsset *appdb.Asset) ([]*model.ChargingSession, error) {
+// fetchWindow is the longest time range requested from the API in one call. Longer ranges are
+// split into consecutive windows, so the initial sync of busy sites doesn't time out.
+const fetchWindow = 30 * 24 * time.Hour
 
-	// create request
-	isoFormat := "2006-01-02T15:04:05Z"
-	fullUrl := fmt.Sprintf("%s/chargelogs?from=%s&to=%s&chargepoint_id=%s", config.RootUrl, dbConnectorAsset.LatestSessionTS.UTC().Format(isoFormat), time.Now().UTC().Format(isoFormat), dbConnectorAsset.ParentProviderID)
-	request, err := request(config, fullUrl)
-	if err != nil {
-		return nil, fmt.Errorf("error requesting %s: %w", fullUrl, err)
-	}
+// pageSize is the number of entries requested per page if the API paginates the results.
+var pageSize = 500
 
-	// read sessions
-	log.Trace("gp-joule", "Reading URL %s", fullUrl)
-	sessions, statusCode, err := utilshttp.ReadWithStatusCode[[]*model.ChargingSession](request, time.Duration(*config.RequestTimeout)*time.Second, true)
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
-	}
+// earliestData is the lower bound for all requests. There is no data in the GP Joule API before
+// this date, so the default cursor of 1900-01-01 would only produce empty windows.
+var earliestData = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// isoFormat is the only time format recognized by the API. The API returns UTC only.
+const isoFormat = "2006-01-02T15:04:05Z"
+
+func (c *apiClient) GetCompletedSessions(chargePointId string, from time.Time, handle func([]*model.ChargingSession, time.Time) error) error {
+	return forEachWindow(from, time.Now(), func(from, to time.Time) error {
+
+		// read sessions
+		baseUrl := fmt.Sprintf("%s/chargelogs?from=%s&to=%s&chargepoint_id=%s", c.config.RootUrl, from.UTC().Format(isoFormat), to.UTC().Format(isoFormat), chargePointId)
+		sessions, err := readPages[*model.ChargingSession](c.config, baseUrl, func(session *model.ChargingSession) string {
+			return session.Id
+		})
+		if err != nil {
+			return err
+		}
+
+		return handle(filterCompletedSessions(sessions), to)
+	})
+}
+
+func (c *apiClient) GetErrorNotifications(chargePointId string, from time.Time, handle func([]*model.ErrorNotification, time.Time) error) error {
+	return forEachWindow(from, time.Now(), func(windowFrom, windowTo time.Time) error {
+
+		// read error notifications
+		baseUrl := fmt.Sprintf("%s/error-notifications?from=%s&to=%s&chargepoint_id=%s", c.config.RootUrl, windowFrom.UTC().Format(isoFormat), windowTo.UTC().Format(isoFormat), chargePointId)
+		notifications, err := readPages[*model.ErrorNotification](c.config, baseUrl, func(notification *model.ErrorNotification) string {
+			return notification.Id
+		})
+		if err != nil {
+			return err
+		}
+
+		return handle(filterErrorNotifications(notifications, from), windowTo)
+	})
+}
 
-	// filtering out sessions all completed sessions belongs to connector
+// filterCompletedSessions returns the completed sessions sorted ascending by their end. Sessions ending at the same
+// time are sorted by their ID, so the order matches the session cursor.
+func filterCompletedSessions(sessions []*model.ChargingSession) []*model.ChargingSession {
+
+	// filtering out all sessions not completed
 	var completedSessions []*model.ChargingSession
 	for _, session := range sessions {
-		if session.ConnectorId == dbConnectorAsset.ProviderID && session.MeterTotal > 0 && session.Status == "stopped" && session.SessionStart != nil && session.SessionEnd != nil {
+		if session.MeterTotal > 0 && session.Status == "stopped" && session.SessionStart != nil && session.SessionEnd != nil {
 			completedSessions = append(completedSessions, session)
 		}
 	}
 
-	// sort ascending by end date
+	// sort ascending by end date and id
 	sort.Slice(completedSessions, func(i, j int) bool {
-		return completedSessions[i].SessionStart.Before(*completedSessions[j].SessionStart)
+		if !completedSessions[i].SessionEnd.Equal(*completedSessions[j].SessionEnd) {
+			return completedSessions[i].SessionEnd.Before(*completedSessions[j].SessionEnd)
+		}
+		return completedSessions[i].Id < completedSessions[j].Id
 	})
 
-	return completedSessions, nil
+	return completedSessions
 }
 
-func GetErrorNotifications(config *apiserver.Configuration, dbConnectorAsset *appdb.Asset) ([]*model.ErrorNotification, error) {
-
-	// create request
-	isoFormat := "2006-01-02T15:04:05Z" // API does only recognize UTC and returns only UTC
-	fullUrl := fmt.Sprintf("%s/error-notifications?from=%s&to=%s&chargepoint_id=%s", config.RootUrl, dbConnectorAsset.LatestErrorTS.UTC().Format(isoFormat), time.Now().UTC().Format(isoFormat), dbConnectorAsset.ParentProviderID)
-	request, err := request(config, fullUrl)
-	if err != nil {
-		return nil, fmt.Errorf("error requesting %s: %w", fullUrl, err)
-	}
-
-	// read error notifications
-	log.Trace("gp-joule", "Reading URL %s", fullUrl)
-	notifications, statusCode, err := utilshttp.ReadWithStatusCode[[]*model.ErrorNotification](request, time.Duration(*config.RequestTimeout)*time.Second, true)
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
-	}
+// filterErrorNotifications returns the error notifications occurred since from sorted ascending. Notifications
+// occurred at from are read again, they are recognized by their ID.
+func filterErrorNotifications(notifications []*model.ErrorNotification, from time.Time) []*model.ErrorNotification {
 
 	// filtering out errors
 	var filteredNotifications []*model.ErrorNotification
 	for _, notification := range notifications {
-		if (notification.ConnectorId == nil || *notification.ConnectorId == dbConnectorAsset.ProviderID) && notification.OccurredAt != nil && notification.OccurredAt.After(dbConnectorAsset.LatestErrorTS) {
+		if notification.OccurredAt != nil && !notification.OccurredAt.Before(from) {
 			filteredNotifications = append(filteredNotifications, notification)
 		}
 	}
@@ -110,7 +172,56 @@ func GetErrorNotifications(config *apiserver.Configuration, dbConnectorAsset *ap
 		return filteredNotifications[i].OccurredAt.Before(*filteredNotifications[j].OccurredAt)
 	})
 
-	return filteredNotifications, nil
+	return filteredNotifications
+}
+
+// forEachWindow splits the range between from and to into consecutive windows of at most fetchWindow
+// and calls fetch for each window in ascending order. It stops at the first error.
+func forEachWindow(from time.Time, to time.Time, fetch func(from, to time.Time) error) error {
+	if from.Before(earliestData) {
+		from = earliestData
+	}
+	for windowFrom := from; windowFrom.Before(to); windowFrom = windowFrom.Add(fetchWindow) {
+		windowTo := windowFrom.Add(fetchWindow)
+		if windowTo.After(to) {
+			windowTo = to
+		}
+		if err := fetch(windowFrom, windowTo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPages reads all entries for the base URL. It requests the entries page by page and stops if a
+// page is not full. If the API ignores the paging parameters, the first page contains all entries
+// and is returned as is. The id function is used to detect an API repeating the same page.
+func readPages[T any](config *apiserver.Configuration, baseUrl string, id func(T) string) ([]T, error) {
+	var entries []T
+	var firstId string
+	for offset := 0; ; offset += pageSize {
+
+		// read page
+		fullUrl := fmt.Sprintf("%s&limit=%d&offset=%d", baseUrl, pageSize, offset)
+		page, err := read[[]T](config, fullUrl)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return entries, nil
+		}
+
+		// stop if the API returns the same page again
+		if offset > 0 && id(page[0]) == firstId {
+			return entries, nil
+		}
+		firstId = id(page[0])
+
+		entries = append(entries, page...)
+		if len(page) != pageSize {
+			return entries, nil
+		}
+	}
 }
 
 func request(config *apiserver.Configuration, fullUrl string) (*http.Request, error) {
@@ -0,0 +1,211 @@
+package gp_joule
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/gp_joule/gp_jouletest"
+	"gp-joule/model"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func testClient(server *gp_jouletest.Server, apiKey string) Client {
+	return NewClient(&apiserver.Configuration{
+		Id:             common.Ptr[int64](100),
+		RootUrl:        server.URL,
+		ApiKey:         apiKey,
+		RequestTimeout: common.Ptr[int32](5),
+	})
+}
+
+func sessionIds(sessions []*model.ChargingSession) []string {
+	var ids []string
+	for _, session := range sessions {
+		ids = append(ids, session.Id)
+	}
+	return ids
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGetClusters(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	clusters, err := testClient(server, gp_jouletest.ApiKey).GetClusters()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 1 || len(clusters[0].ChargePoints) != 2 || len(clusters[0].ChargePoints[0].Connectors) != 2 {
+		t.Errorf("unexpected clusters %+v", clusters)
+	}
+}
+
+func TestGetClustersWithWrongApiKey(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	if _, err := testClient(server, "wrong").GetClusters(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestGetCompletedSessions(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	var sessions []*model.ChargingSession
+	err := testClient(server, gp_jouletest.ApiKey).GetCompletedSessions("cp-1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), func(window []*model.ChargingSession, _ time.Time) error {
+		sessions = append(sessions, window...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := sessionIds(sessions); !equal(ids, []string{"s-1", "s-3"}) {
+		t.Errorf("unexpected sessions %v", ids)
+	}
+}
+
+func TestGetCompletedSessionsPaginated(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	defer func(size int) { pageSize = size }(pageSize)
+	pageSize = 1
+
+	var sessions []*model.ChargingSession
+	err := testClient(server, gp_jouletest.ApiKey).GetCompletedSessions("cp-1", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), func(window []*model.ChargingSession, _ time.Time) error {
+		sessions = append(sessions, window...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := sessionIds(sessions); !equal(ids, []string{"s-1", "s-3"}) {
+		t.Errorf("unexpected sessions %v", ids)
+	}
+	// all 4 sessions of cp-1 are in the first window, so it needs 4 full pages and an empty one
+	windows := 0
+	_ = forEachWindow(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), time.Now(), func(from, to time.Time) error {
+		windows++
+		return nil
+	})
+	if requests := server.Requests("/chargelogs"); requests != windows+4 {
+		t.Errorf("expected %d requests, got %d", windows+4, requests)
+	}
+}
+
+func TestGetCompletedSessionsReadUntil(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	// empty windows are passed as well, so the caller knows how far it read
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var windows []time.Time
+	err := testClient(server, gp_jouletest.ApiKey).GetCompletedSessions("cp-unknown", from, func(window []*model.ChargingSession, readUntil time.Time) error {
+		if len(window) != 0 {
+			t.Errorf("unexpected sessions %v", sessionIds(window))
+		}
+		windows = append(windows, readUntil)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(windows) == 0 || !windows[0].Equal(from.Add(fetchWindow)) || time.Since(windows[len(windows)-1]) > time.Minute {
+		t.Errorf("unexpected windows read until %v", windows)
+	}
+}
+
+func TestFilterCompletedSessionsOverlapping(t *testing.T) {
+	at := func(hour int) *time.Time {
+		return common.Ptr(time.Date(2024, 4, 1, hour, 0, 0, 0, time.UTC))
+	}
+
+	// overlapping sessions on one connector, b and c end at the same time
+	sessions := []*model.ChargingSession{
+		{Id: "a", ConnectorId: "con-1-1", Status: "stopped", MeterTotal: 1, SessionStart: at(8), SessionEnd: at(12)},
+		{Id: "c", ConnectorId: "con-1-1", Status: "stopped", MeterTotal: 1, SessionStart: at(9), SessionEnd: at(10)},
+		{Id: "b", ConnectorId: "con-1-1", Status: "stopped", MeterTotal: 1, SessionStart: at(9), SessionEnd: at(10)},
+		{Id: "d", ConnectorId: "con-1-1", Status: "stopped", MeterTotal: 1, SessionStart: at(7), SessionEnd: at(9)},
+	}
+	if ids := sessionIds(filterCompletedSessions(sessions)); !equal(ids, []string{"d", "b", "c", "a"}) {
+		t.Errorf("unexpected order %v", ids)
+	}
+}
+
+func TestGetErrorNotifications(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	var ids []string
+	from := time.Date(2024, 4, 1, 7, 0, 0, 0, time.UTC)
+	err := testClient(server, gp_jouletest.ApiKey).GetErrorNotifications("cp-1", from, func(notifications []*model.ErrorNotification, _ time.Time) error {
+		for _, notification := range notifications {
+			ids = append(ids, notification.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// notifications occurred at from are included
+	if !equal(ids, []string{"e-1", "e-2", "e-3"}) {
+		t.Errorf("unexpected error notifications %v", ids)
+	}
+}
+
+func TestGetErrorNotificationsOfChargePoint(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	// errors of the whole charge point are passed once without connector
+	var chargePointIds []string
+	err := testClient(server, gp_jouletest.ApiKey).GetErrorNotifications("cp-1", time.Time{}, func(notifications []*model.ErrorNotification, _ time.Time) error {
+		for _, notification := range notifications {
+			if notification.ConnectorId == nil {
+				chargePointIds = append(chargePointIds, notification.Id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !equal(chargePointIds, []string{"e-2"}) {
+		t.Errorf("unexpected charge point error notifications %v", chargePointIds)
+	}
+}
+
+func TestFakeClientMatchesApiClient(t *testing.T) {
+	server := gp_jouletest.NewServer()
+	defer server.Close()
+
+	fake := &FakeClient{Clusters: server.Clusters, Sessions: server.Sessions, ErrorNotifications: server.ErrorNotifications}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, client := range []Client{fake, testClient(server, gp_jouletest.ApiKey)} {
+		var sessions []*model.ChargingSession
+		err := client.GetCompletedSessions("cp-2", from, func(window []*model.ChargingSession, _ time.Time) error {
+			sessions = append(sessions, window...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ids := sessionIds(sessions); !equal(ids, []string{"s-4"}) {
+			t.Errorf("%T: unexpected sessions %v", client, ids)
+		}
+	}
+}
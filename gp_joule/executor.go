@@ -0,0 +1,193 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package gp_joule
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"gp-joule/apiserver"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// retryBaseDelay is the delay before the first retry. The delay doubles with each further retry.
+const retryBaseDelay = time.Second
+
+// retryMaxDelay limits the delay between two retries, including delays requested by Retry-After.
+const retryMaxDelay = 5 * time.Minute
+
+// read executes a GET request for the URL and decodes the JSON response. Requests failing temporarily, like
+// network errors, status 429 or 5xx, are retried up to MaxRetries times with jittered exponential backoff.
+// A Retry-After header sent by the API takes precedence over the backoff. All requests of a configuration
+// share the rate limit defined by RateLimit.
+func read[T any](config *apiserver.Configuration, fullUrl string) (T, error) {
+	var value T
+
+	var maxRetries int32
+	if config.MaxRetries != nil {
+		maxRetries = *config.MaxRetries
+	}
+
+	for attempt := int32(0); ; attempt++ {
+
+		// create request
+		request, err := request(config, fullUrl)
+		if err != nil {
+			return value, fmt.Errorf("error requesting %s: %w", fullUrl, err)
+		}
+
+		// read response
+		rateLimiterFor(config).wait()
+		log.Trace("gp-joule", "Reading URL %s", fullUrl)
+		payload, statusCode, retryAfter, err := do(config, request)
+		if err == nil && statusCode == http.StatusOK {
+			if len(payload) == 0 {
+				return value, nil
+			}
+			if err := json.Unmarshal(payload, &value); err != nil {
+				return value, fmt.Errorf("error unmarshalling response for %s: %w", fullUrl, err)
+			}
+			return value, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status code %d", statusCode)
+		}
+
+		// give up if the error is permanent or all retries are used
+		if !isTemporary(statusCode) || attempt >= maxRetries {
+			return value, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
+		}
+
+		delay := backoff(attempt, retryAfter)
+		log.Warn("gp-joule", "Reading %s failed (%d %v), retry %d of %d in %v", fullUrl, statusCode, err, attempt+1, maxRetries, delay)
+		time.Sleep(delay)
+	}
+}
+
+// do executes the request and returns the payload, the status code and the delay requested by a
+// Retry-After header, if any.
+func do(config *apiserver.Configuration, request *http.Request) ([]byte, int, time.Duration, error) {
+	httpClient := http.Client{
+		Timeout: time.Duration(*config.RequestTimeout) * time.Second,
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Error("gp-joule", "Error closing request for %s: %v", request.URL, err)
+		}
+	}(response.Body)
+
+	payload, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, response.StatusCode, 0, err
+	}
+	return payload, response.StatusCode, parseRetryAfter(response.Header.Get("Retry-After"), time.Now()), nil
+}
+
+// isTemporary returns true for network errors (status code 0) and status codes worth a retry.
+func isTemporary(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the delay before the next retry. It is the delay requested by the API or
+// the exponential backoff with full jitter, limited to retryMaxDelay.
+func backoff(attempt int32, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, retryMaxDelay)
+	}
+	delay := retryBaseDelay
+	for i := int32(0); i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retryMaxDelay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds
+// or an HTTP date. It returns 0 if the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// rateLimiter spaces requests evenly to not exceed a number of requests per minute.
+type rateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request is allowed.
+func (l *rateLimiter) wait() {
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mutex.Unlock()
+
+	time.Sleep(delay)
+}
+
+var rateLimiters = make(map[int64]*rateLimiter)
+var rateLimitersMutex sync.Mutex
+
+// rateLimiterFor returns the rate limiter shared by all requests of the configuration.
+func rateLimiterFor(config *apiserver.Configuration) *rateLimiter {
+	var interval time.Duration
+	if config.RateLimit != nil && *config.RateLimit > 0 {
+		interval = time.Minute / time.Duration(*config.RateLimit)
+	}
+
+	var id int64
+	if config.Id != nil {
+		id = *config.Id
+	}
+
+	rateLimitersMutex.Lock()
+	defer rateLimitersMutex.Unlock()
+	limiter, ok := rateLimiters[id]
+	if !ok {
+		limiter = &rateLimiter{}
+		rateLimiters[id] = limiter
+	}
+
+	// the configuration could have been changed in the meantime
+	limiter.mutex.Lock()
+	limiter.interval = interval
+	limiter.mutex.Unlock()
+
+	return limiter
+}
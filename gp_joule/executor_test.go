@@ -0,0 +1,106 @@
+package gp_joule
+
+import (
+	"gp-joule/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"-3", 0},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second},
+		{"Wed, 01 May 2024 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, test := range tests {
+		if got := parseRetryAfter(test.value, now); got != test.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, attempt := range []int32{0, 1, 8, 34, 64, 1000} {
+		delay := backoff(attempt, 0)
+		if delay < retryBaseDelay/2 || delay > retryMaxDelay {
+			t.Errorf("backoff(%d) = %v out of range", attempt, delay)
+		}
+	}
+	if delay := backoff(1000, 0); delay < retryMaxDelay/2 {
+		t.Errorf("backoff(1000) = %v, expected at least %v", delay, retryMaxDelay/2)
+	}
+	if delay := backoff(3, time.Hour); delay != retryMaxDelay {
+		t.Errorf("backoff with Retry-After = %v, want %v", delay, retryMaxDelay)
+	}
+}
+
+func TestReadRetriesTemporaryErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`["ok"]`))
+	}))
+	defer server.Close()
+
+	config := &apiserver.Configuration{Id: common.Ptr[int64](1), RequestTimeout: common.Ptr[int32](5), MaxRetries: common.Ptr[int32](1)}
+	value, err := read[[]string](config, server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 || len(value) != 1 || value[0] != "ok" {
+		t.Errorf("got %v after %d calls", value, calls)
+	}
+}
+
+func TestReadDoesNotRetryPermanentErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	config := &apiserver.Configuration{Id: common.Ptr[int64](2), RequestTimeout: common.Ptr[int32](5), MaxRetries: common.Ptr[int32](3)}
+	if _, err := read[[]string](config, server.URL); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestPreviewClientDoesNotRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &apiserver.Configuration{Id: common.Ptr[int64](3), RootUrl: server.URL, RequestTimeout: common.Ptr[int32](120), MaxRetries: common.Ptr[int32](3), RateLimit: common.Ptr[int32](1)}
+	if _, err := NewPreviewClient(config).GetClusters(); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+	if *config.MaxRetries != 3 || *config.RequestTimeout != 120 || *config.RateLimit != 1 {
+		t.Errorf("preview client modified the configuration %+v", config)
+	}
+}
@@ -0,0 +1,77 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package gp_joule
+
+import (
+	"gp-joule/model"
+	"time"
+)
+
+// FakeClient is an in-memory Client serving the given data like the GP Joule API in a single window up to now. It is
+// meant for tests.
+type FakeClient struct {
+	Clusters           []*model.Cluster
+	Sessions           []*model.ChargingSession
+	ErrorNotifications []*model.ErrorNotification
+
+	// Err is returned by all methods if set
+	Err error
+
+	// SessionsReadFrom and ErrorsReadFrom record the time read from on the latest call by charge point
+	SessionsReadFrom map[string]time.Time
+	ErrorsReadFrom   map[string]time.Time
+}
+
+func (f *FakeClient) GetClusters() ([]*model.Cluster, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return f.Clusters, nil
+}
+
+func (f *FakeClient) GetCompletedSessions(chargePointId string, from time.Time, handle func([]*model.ChargingSession, time.Time) error) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	if f.SessionsReadFrom == nil {
+		f.SessionsReadFrom = make(map[string]time.Time)
+	}
+	f.SessionsReadFrom[chargePointId] = from
+	var sessions []*model.ChargingSession
+	for _, session := range f.Sessions {
+		if session.ChargePointId == chargePointId && (session.SessionEnd == nil || !session.SessionEnd.Before(from)) {
+			sessions = append(sessions, session)
+		}
+	}
+	return handle(filterCompletedSessions(sessions), time.Now())
+}
+
+func (f *FakeClient) GetErrorNotifications(chargePointId string, from time.Time, handle func([]*model.ErrorNotification, time.Time) error) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	if f.ErrorsReadFrom == nil {
+		f.ErrorsReadFrom = make(map[string]time.Time)
+	}
+	f.ErrorsReadFrom[chargePointId] = from
+	var notifications []*model.ErrorNotification
+	for _, notification := range f.ErrorNotifications {
+		if notification.ChargePointId == chargePointId {
+			notifications = append(notifications, notification)
+		}
+	}
+	return handle(filterErrorNotifications(notifications, from), time.Now())
+}
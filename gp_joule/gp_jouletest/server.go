@@ -0,0 +1,161 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package gp_jouletest provides a stand-in for the GP Joule API serving fixture data. It is meant for tests
+// running without access to the real API.
+package gp_jouletest
+
+import (
+	"embed"
+	"encoding/json"
+	"gp-joule/model"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// ApiKey is the only API key accepted by the server.
+const ApiKey = "secret"
+
+// Server answers requests like the GP Joule API using the fixtures. The data can be changed by tests.
+type Server struct {
+	*httptest.Server
+
+	Clusters           []*model.Cluster
+	Sessions           []*model.ChargingSession
+	ErrorNotifications []*model.ErrorNotification
+
+	mutex    sync.Mutex
+	requests map[string]int
+}
+
+// NewServer starts a server with the data from the fixtures. The server must be closed by the caller.
+func NewServer() *Server {
+	s := &Server{requests: make(map[string]int)}
+	s.Clusters, s.Sessions, s.ErrorNotifications = Fixtures()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/clusters", func(w http.ResponseWriter, r *http.Request) {
+		write(w, s.Clusters)
+	})
+	mux.HandleFunc("/chargelogs", func(w http.ResponseWriter, r *http.Request) {
+		from, to, ok := timeRange(w, r)
+		if !ok {
+			return
+		}
+		var sessions []*model.ChargingSession
+		for _, session := range s.Sessions {
+			ts := session.SessionStart
+			if session.SessionEnd != nil {
+				ts = session.SessionEnd
+			}
+			if session.ChargePointId == r.URL.Query().Get("chargepoint_id") && ts != nil && !ts.Before(from) && ts.Before(to) {
+				sessions = append(sessions, session)
+			}
+		}
+		write(w, page(sessions, r))
+	})
+	mux.HandleFunc("/error-notifications", func(w http.ResponseWriter, r *http.Request) {
+		from, to, ok := timeRange(w, r)
+		if !ok {
+			return
+		}
+		var notifications []*model.ErrorNotification
+		for _, notification := range s.ErrorNotifications {
+			if notification.ChargePointId == r.URL.Query().Get("chargepoint_id") && notification.OccurredAt != nil && !notification.OccurredAt.Before(from) && notification.OccurredAt.Before(to) {
+				notifications = append(notifications, notification)
+			}
+		}
+		write(w, page(notifications, r))
+	})
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.requests[r.URL.Path]++
+		s.mutex.Unlock()
+		if r.Header.Get("x-api-key") != ApiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// Requests returns the number of requests received for the path, e.g. "/chargelogs".
+func (s *Server) Requests(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[path]
+}
+
+// Fixtures returns a fresh copy of the fixture data, e.g. to fill a gp_joule.FakeClient.
+func Fixtures() ([]*model.Cluster, []*model.ChargingSession, []*model.ErrorNotification) {
+	var clusters []*model.Cluster
+	var sessions []*model.ChargingSession
+	var notifications []*model.ErrorNotification
+	mustLoad("fixtures/clusters.json", &clusters)
+	mustLoad("fixtures/chargelogs.json", &sessions)
+	mustLoad("fixtures/error-notifications.json", &notifications)
+	return clusters, sessions, notifications
+}
+
+func mustLoad(name string, value any) {
+	data, err := fixtures.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		panic(err)
+	}
+}
+
+// timeRange parses the from and to parameters. It answers with status 400 if they are invalid.
+func timeRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// page returns the entries selected by the limit and offset parameters.
+func page[T any](entries []T, r *http.Request) []T {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(entries)
+	}
+	if offset >= len(entries) {
+		return []T{}
+	}
+	return entries[offset:min(offset+limit, len(entries))]
+}
+
+func write(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
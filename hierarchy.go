@@ -0,0 +1,98 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/conf"
+	"gp-joule/eliona"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// collectParents maps the GAI of the node and all its locational descendants to the GAI of their parent.
+func collectParents(node asset.LocationalNode, parentGAI string, parents map[string]string) {
+	parents[node.GetGAI()] = parentGAI
+	for _, child := range node.GetLocationalChildren() {
+		collectParents(child, node.GetGAI(), parents)
+	}
+}
+
+// assetMove is an asset that has to be moved below a new parent.
+type assetMove struct {
+	dbAsset       *appdb.Asset
+	dbParentAsset *appdb.Asset
+}
+
+// reparentAssets moves the assets of the project whose parent changed in GP Joule, e.g. a charge point
+// re-assigned to another cluster. The parents map the GAIs of all assets present in GP Joule to the GAIs
+// of their parents.
+func reparentAssets(config *apiserver.Configuration, projectId string, parents map[string]string) error {
+	dbAssets, err := conf.GetAssets(context.Background(), config, projectId)
+	if err != nil {
+		return err
+	}
+
+	for _, move := range movedAssets(dbAssets, parents) {
+
+		// check if asset still exists in Eliona
+		exists, err := asset.ExistAsset(move.dbAsset.AssetID.Int32)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := eliona.MoveAsset(move.dbAsset.AssetID.Int32, move.dbParentAsset.AssetID.Int32); err != nil {
+				return err
+			}
+		}
+
+		// remember new parent
+		move.dbAsset.ParentProviderID = move.dbParentAsset.ProviderID
+		_, err = move.dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.ParentProviderID))
+		if err != nil {
+			return err
+		}
+		log.Info("eliona", "Moved asset %d below asset %d", move.dbAsset.AssetID.Int32, move.dbParentAsset.AssetID.Int32)
+	}
+	return nil
+}
+
+// movedAssets returns the assets whose parent in GP Joule differs from the parent stored. The parent is
+// identified by the provider id of the parent asset. Removed assets are skipped.
+func movedAssets(dbAssets appdb.AssetSlice, parents map[string]string) []assetMove {
+	dbAssetsByGAI := make(map[string]*appdb.Asset)
+	for _, dbAsset := range dbAssets {
+		dbAssetsByGAI[dbAsset.GlobalAssetID] = dbAsset
+	}
+
+	var moves []assetMove
+	for _, dbAsset := range dbAssets {
+		parentGAI, ok := parents[dbAsset.GlobalAssetID]
+		if !ok || dbAsset.RemovedAt.Valid {
+			continue
+		}
+		dbParentAsset := dbAssetsByGAI[parentGAI]
+		if dbParentAsset == nil || dbParentAsset.ProviderID == dbAsset.ParentProviderID {
+			continue
+		}
+		moves = append(moves, assetMove{dbAsset: dbAsset, dbParentAsset: dbParentAsset})
+	}
+	return moves
+}
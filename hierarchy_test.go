@@ -0,0 +1,67 @@
+package main
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/gp_joule/gp_jouletest"
+	"gp-joule/model"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestCollectParents(t *testing.T) {
+	clusters, _, _ := gp_jouletest.Fixtures()
+	parents := make(map[string]string)
+	collectParents(&model.Root{Config: &apiserver.Configuration{Id: common.Ptr(int64(1))}, Clusters: clusters}, "", parents)
+
+	expected := map[string]string{
+		"gp_joule_root_1":              "",
+		"gp_joule_cluster_1_Parking A": "gp_joule_root_1",
+		"gp_joule_charge_point_cp-1":   "gp_joule_cluster_1_Parking A",
+		"gp_joule_connector_con-1-1":   "gp_joule_charge_point_cp-1",
+		"gp_joule_session_log_con-1-1": "gp_joule_connector_con-1-1",
+		"gp_joule_connector_con-2-1":   "gp_joule_charge_point_cp-2",
+		"gp_joule_session_log_con-2-1": "gp_joule_connector_con-2-1",
+	}
+	for gai, parent := range expected {
+		if actual, ok := parents[gai]; !ok || actual != parent {
+			t.Errorf("%s: expected parent %q, got %q", gai, parent, actual)
+		}
+	}
+}
+
+func TestMovedAssets(t *testing.T) {
+	clusters, _, _ := gp_jouletest.Fixtures()
+	parents := make(map[string]string)
+	collectParents(&model.Root{Config: &apiserver.Configuration{Id: common.Ptr(int64(1))}, Clusters: clusters}, "", parents)
+
+	oldCluster := &appdb.Asset{GlobalAssetID: "gp_joule_cluster_1_Parking B", ProviderID: "Parking B"}
+	cluster := &appdb.Asset{GlobalAssetID: "gp_joule_cluster_1_Parking A", ProviderID: "Parking A"}
+	movedChargePoint := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-1", ProviderID: "cp-1", ParentProviderID: "Parking B"}
+	chargePoint := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-2", ProviderID: "cp-2", ParentProviderID: "Parking A"}
+	removedConnector := &appdb.Asset{GlobalAssetID: "gp_joule_connector_con-1-1", ProviderID: "con-1-1", ParentProviderID: "cp-2", RemovedAt: null.TimeFrom(time.Now())}
+	connector := &appdb.Asset{GlobalAssetID: "gp_joule_connector_con-2-1", ProviderID: "con-2-1", ParentProviderID: "cp-2"}
+
+	moves := movedAssets(appdb.AssetSlice{oldCluster, cluster, movedChargePoint, chargePoint, removedConnector, connector}, parents)
+	if len(moves) != 1 || moves[0].dbAsset != movedChargePoint || moves[0].dbParentAsset != cluster {
+		t.Errorf("unexpected moves %v", moves)
+	}
+}
+
+func TestMovedAssetsBelowScopedRoot(t *testing.T) {
+	clusters, _, _ := gp_jouletest.Fixtures()
+	parents := make(map[string]string)
+	collectParents(&model.Root{Config: &apiserver.Configuration{Id: common.Ptr(int64(2))}, Clusters: clusters}, "", parents)
+
+	// cluster still below the legacy root shared with another configuration
+	root := &appdb.Asset{GlobalAssetID: "gp_joule_root_2", ProviderID: "2"}
+	cluster := &appdb.Asset{GlobalAssetID: "gp_joule_cluster_2_Parking A", ProviderID: "Parking A"}
+
+	moves := movedAssets(appdb.AssetSlice{root, cluster}, parents)
+	if len(moves) != 1 || moves[0].dbAsset != cluster || moves[0].dbParentAsset != root {
+		t.Errorf("unexpected moves %v", moves)
+	}
+}
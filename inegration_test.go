@@ -20,9 +20,10 @@ func assetTypes(t *testing.T) {
 	t.Parallel()
 
 	assert.AssetTypeExists(t, "gp_joule_charge_point", []string{"model"})
-	assert.AssetTypeExists(t, "gp_joule_cluster", []string{})
+	assert.AssetTypeExists(t, "gp_joule_cluster", []string{"connectors_total"})
 	assert.AssetTypeExists(t, "gp_joule_connector", []string{"status"})
 	assert.AssetTypeExists(t, "gp_joule_root", []string{})
+	assert.AssetTypeExists(t, "gp_joule_archive", []string{})
 	assert.AssetTypeExists(t, "gp_joule_session_log", []string{"energy"})
 }
 
@@ -35,5 +36,5 @@ func widgetTypes(t *testing.T) {
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "gp_joule", []string{"configuration", "asset"})
+	assert.SchemaExists(t, "gp_joule", []string{"configuration", "asset", "session", "error"})
 }
@@ -0,0 +1,136 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/conf"
+	"gp-joule/eliona"
+	"gp-joule/model"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// legacyRootGAI and legacyArchiveGAI are the GAIs used before they were scoped to the configuration.
+const (
+	legacyRootGAI    = "gp_joule_root"
+	legacyArchiveGAI = "gp_joule_archive"
+)
+
+// legacyClusterGAI returns the GAI of the cluster used before it was scoped to the configuration.
+func legacyClusterGAI(cluster *model.Cluster) string {
+	return cluster.GetAssetType() + "_" + cluster.Name
+}
+
+// migrateScopedAssets moves the root, archive and cluster assets of the project from the legacy GAIs to the GAIs
+// scoped to the configuration. The first configuration migrating a shared legacy asset keeps it. The other
+// configurations drop their mapping, so they get their own root and clusters and the assets below are moved.
+func migrateScopedAssets(config *apiserver.Configuration, projectId string, root *model.Root) error {
+	migrated, err := migrateScopedAsset(config, projectId, legacyRootGAI, root.GetGAI(), root.ProviderId())
+	if err != nil {
+		return err
+	}
+	if migrated {
+
+		// keep the clusters below the root
+		_, err = appdb.Assets(
+			appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+			appdb.AssetWhere.ProjectID.EQ(projectId),
+			appdb.AssetWhere.AssetType.EQ(null.StringFrom((&model.Cluster{}).GetAssetType())),
+			appdb.AssetWhere.ParentProviderID.EQ(""),
+		).UpdateAllG(context.Background(), appdb.M{
+			appdb.AssetColumns.ParentProviderID: root.ProviderId(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	archive := &model.Archive{Config: config}
+	_, err = migrateScopedAsset(config, projectId, legacyArchiveGAI, archive.GetGAI(), "")
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range root.Clusters {
+		scoped := &model.Cluster{Name: cluster.Name, Config: config}
+		legacyGAI := legacyClusterGAI(scoped)
+		dbAsset, err := conf.GetAsset(context.Background(), config, projectId, legacyGAI)
+		if err != nil {
+			return err
+		}
+		if dbAsset == nil {
+			continue
+		}
+		migrated, err := migrateScopedAsset(config, projectId, legacyGAI, scoped.GetGAI(), cluster.Name)
+		if err != nil {
+			return err
+		}
+		if migrated {
+			continue
+		}
+
+		// move the charge points below the own cluster created instead of the one claimed by another configuration
+		_, err = appdb.Assets(
+			appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+			appdb.AssetWhere.ProjectID.EQ(projectId),
+			appdb.AssetWhere.AssetType.EQ(null.StringFrom((&model.ChargePoint{}).GetAssetType())),
+			appdb.AssetWhere.ParentProviderID.EQ(cluster.Name),
+		).UpdateAllG(context.Background(), appdb.M{
+			appdb.AssetColumns.ParentProviderID: "",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateScopedAsset changes the GAI of the asset mapped to legacyGAI in Eliona and in the mapping. If the asset
+// doesn't exist anymore or was claimed by another configuration, the mapping is deleted. It returns true if the
+// asset was migrated.
+func migrateScopedAsset(config *apiserver.Configuration, projectId string, legacyGAI string, gai string, providerId string) (bool, error) {
+	dbAsset, err := conf.GetAsset(context.Background(), config, projectId, legacyGAI)
+	if err != nil || dbAsset == nil {
+		return false, err
+	}
+
+	claimed, err := eliona.ClaimAssetGAI(dbAsset.AssetID.Int32, legacyGAI, gai)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		_, err = dbAsset.DeleteG(context.Background())
+		if err != nil {
+			return false, err
+		}
+		log.Info("eliona", "Dropped mapping of asset %d to %s for config %d", dbAsset.AssetID.Int32, legacyGAI, *config.Id)
+		return false, nil
+	}
+
+	dbAsset.GlobalAssetID = gai
+	dbAsset.ProviderID = providerId
+	_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.GlobalAssetID, appdb.AssetColumns.ProviderID))
+	if err != nil {
+		return false, err
+	}
+	log.Info("eliona", "Migrated asset %d from %s to %s", dbAsset.AssetID.Int32, legacyGAI, gai)
+	return true, nil
+}
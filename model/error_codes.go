@@ -0,0 +1,127 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package model
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/conf"
+)
+
+// errorCodes is the built-in catalogue of the OCPP error codes reported by GP Joule.
+var errorCodes = []apiserver.ErrorCode{
+	errorCode("ConnectorLockFailure", conf.ErrorSeverityMedium, "Verriegelung des Steckers fehlgeschlagen", "Connector lock failure", "Échec du verrouillage du connecteur", "Guasto del blocco del connettore"),
+	errorCode("EVCommunicationError", conf.ErrorSeverityLow, "Kommunikationsfehler mit dem Fahrzeug", "Communication error with the vehicle", "Erreur de communication avec le véhicule", "Errore di comunicazione con il veicolo"),
+	errorCode("GroundFailure", conf.ErrorSeverityHigh, "Erdschluss", "Ground failure", "Défaut de terre", "Guasto a terra"),
+	errorCode("HighTemperature", conf.ErrorSeverityHigh, "Übertemperatur", "High temperature", "Température élevée", "Temperatura elevata"),
+	errorCode("InternalError", conf.ErrorSeverityMedium, "Interner Fehler", "Internal error", "Erreur interne", "Errore interno"),
+	errorCode("LocalListConflict", conf.ErrorSeverityLow, "Konflikt der lokalen Autorisierungsliste", "Local authorization list conflict", "Conflit de la liste d'autorisation locale", "Conflitto della lista di autorizzazione locale"),
+	errorCode("OtherError", conf.ErrorSeverityMedium, "Sonstiger Fehler", "Other error", "Autre erreur", "Altro errore"),
+	errorCode("OverCurrentFailure", conf.ErrorSeverityHigh, "Überstrom", "Over current", "Surintensité", "Sovracorrente"),
+	errorCode("OverVoltage", conf.ErrorSeverityHigh, "Überspannung", "Over voltage", "Surtension", "Sovratensione"),
+	errorCode("PowerMeterFailure", conf.ErrorSeverityMedium, "Fehler des Energiezählers", "Power meter failure", "Défaillance du compteur d'énergie", "Guasto del contatore di energia"),
+	errorCode("PowerSwitchFailure", conf.ErrorSeverityHigh, "Fehler des Leistungsschalters", "Power switch failure", "Défaillance de l'interrupteur de puissance", "Guasto dell'interruttore di potenza"),
+	errorCode("ReaderFailure", conf.ErrorSeverityLow, "Fehler des RFID-Lesers", "RFID reader failure", "Défaillance du lecteur RFID", "Guasto del lettore RFID"),
+	errorCode("ResetFailure", conf.ErrorSeverityMedium, "Neustart fehlgeschlagen", "Reset failure", "Échec de la réinitialisation", "Ripristino non riuscito"),
+	errorCode("UnderVoltage", conf.ErrorSeverityMedium, "Unterspannung", "Under voltage", "Sous-tension", "Sottotensione"),
+	errorCode("WeakSignal", conf.ErrorSeverityLow, "Schwaches Mobilfunksignal", "Weak signal", "Signal faible", "Segnale debole"),
+}
+
+// vendorCodes is the built-in catalogue of the vendor codes reported by GP Joule in addition to the OCPP error code.
+var vendorCodes = []apiserver.ErrorCode{
+	errorCode("0x1F", conf.ErrorSeverityMedium, "Verbindung zum Backend unterbrochen", "Lost connection to backend", "Connexion au backend perdue", "Connessione al backend persa"),
+}
+
+func errorCode(code string, severity string, de string, en string, fr string, it string) apiserver.ErrorCode {
+	return apiserver.ErrorCode{
+		Code:        code,
+		Severity:    severity,
+		Translation: map[string]string{"de": de, "en": en, "fr": fr, "it": it},
+	}
+}
+
+// ErrorCatalogue provides the text and severity of errors. It combines the built-in catalogue with the entries of
+// the configuration, which override the built-in entries with the same code.
+type ErrorCatalogue struct {
+	entries  map[string]apiserver.ErrorCode
+	language string
+}
+
+// NewErrorCatalogue creates the error catalogue of the configuration. Translations and severity not given by an
+// entry of the configuration are taken from the built-in entry.
+func NewErrorCatalogue(config *apiserver.Configuration) *ErrorCatalogue {
+	catalogue := &ErrorCatalogue{entries: make(map[string]apiserver.ErrorCode), language: "en"}
+	for _, entry := range errorCodes {
+		catalogue.entries[entry.Code] = entry
+	}
+	for _, entry := range vendorCodes {
+		catalogue.entries[entry.Code] = entry
+	}
+	if config == nil {
+		return catalogue
+	}
+	if config.ErrorLanguage != nil && *config.ErrorLanguage != "" {
+		catalogue.language = *config.ErrorLanguage
+	}
+
+	for _, override := range config.ErrorCodes {
+		entry := apiserver.ErrorCode{Code: override.Code, Translation: make(map[string]string)}
+		if builtIn, ok := catalogue.entries[override.Code]; ok {
+			entry.Severity = builtIn.Severity
+			for language, text := range builtIn.Translation {
+				entry.Translation[language] = text
+			}
+		}
+		if override.Severity != "" {
+			entry.Severity = override.Severity
+		}
+		for language, text := range override.Translation {
+			entry.Translation[language] = text
+		}
+		catalogue.entries[override.Code] = entry
+	}
+	return catalogue
+}
+
+// entry returns the entry of the vendor code if known, otherwise the entry of the error code. Unknown codes
+// return an empty entry.
+func (c *ErrorCatalogue) entry(errorCode string, vendorCode string) apiserver.ErrorCode {
+	if entry, ok := c.entries[vendorCode]; ok && vendorCode != "" {
+		return entry
+	}
+	return c.entries[errorCode]
+}
+
+// Text returns the text of the error in the language of the configuration. Missing translations fall back to
+// English, unknown codes to the error code itself.
+func (c *ErrorCatalogue) Text(errorCode string, vendorCode string) string {
+	entry := c.entry(errorCode, vendorCode)
+	if text := entry.Translation[c.language]; text != "" {
+		return text
+	}
+	if text := entry.Translation["en"]; text != "" {
+		return text
+	}
+	return errorCode
+}
+
+// Severity returns the severity of the error. Unknown codes are of medium severity.
+func (c *ErrorCatalogue) Severity(errorCode string, vendorCode string) string {
+	entry := c.entry(errorCode, vendorCode)
+	if entry.Severity == "" {
+		return conf.ErrorSeverityMedium
+	}
+	return entry.Severity
+}
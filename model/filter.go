@@ -0,0 +1,238 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package model
+
+import (
+	"fmt"
+	"gp-joule/apiserver"
+	"gp-joule/conf"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FilterClusters returns the clusters with only the charge points and connectors adhering to the filter. The filter
+// is evaluated for each connector on the properties of the connector, its charge point and its cluster, so rules
+// for different levels can be combined. Charge points and clusters are kept if at least one of their connectors is
+// kept. The clusters passed are not modified.
+func FilterClusters(clusters []*Cluster, filter [][]apiserver.FilterRule) ([]*Cluster, error) {
+	if len(filter) == 0 {
+		return clusters, nil
+	}
+
+	var filteredClusters []*Cluster
+	for _, cluster := range clusters {
+		var chargePoints []*ChargePoint
+		for _, chargePoint := range cluster.ChargePoints {
+			if len(chargePoint.Connectors) == 0 {
+				adheres, err := matchesFilter(filter, chargePointFilterProperties(cluster, chargePoint, nil))
+				if err != nil {
+					return nil, err
+				}
+				if adheres {
+					chargePoints = append(chargePoints, chargePoint)
+				}
+				continue
+			}
+
+			var connectors []*Connector
+			for _, connector := range chargePoint.Connectors {
+				adheres, err := matchesFilter(filter, chargePointFilterProperties(cluster, chargePoint, connector))
+				if err != nil {
+					return nil, err
+				}
+				if adheres {
+					connectors = append(connectors, connector)
+				}
+			}
+			if len(connectors) > 0 {
+				filteredChargePoint := *chargePoint
+				filteredChargePoint.Connectors = connectors
+				chargePoints = append(chargePoints, &filteredChargePoint)
+			}
+		}
+		if len(chargePoints) > 0 {
+			filteredCluster := *cluster
+			filteredCluster.ChargePoints = chargePoints
+			filteredClusters = append(filteredClusters, &filteredCluster)
+		}
+	}
+	return filteredClusters, nil
+}
+
+// PreviewFilter returns the tree of the clusters with each node marked whether it adheres to the filter the
+// same way as by FilterClusters.
+func PreviewFilter(clusters []*Cluster, filter [][]apiserver.FilterRule) ([]apiserver.FilterPreviewNode, error) {
+	filteredClusters, err := FilterClusters(clusters, filter)
+	if err != nil {
+		return nil, err
+	}
+	included := make(map[string]bool)
+	for _, cluster := range filteredClusters {
+		included["cluster_"+cluster.Name] = true
+		for _, chargePoint := range cluster.ChargePoints {
+			included["charge_point_"+chargePoint.ChargePointId] = true
+			for _, connector := range chargePoint.Connectors {
+				included["connector_"+connector.ConnectorId] = true
+			}
+		}
+	}
+
+	nodes := make([]apiserver.FilterPreviewNode, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterNode := apiserver.FilterPreviewNode{Kind: "cluster", Id: cluster.Name, Name: cluster.Name, Included: included["cluster_"+cluster.Name]}
+		for _, chargePoint := range cluster.ChargePoints {
+			chargePointNode := apiserver.FilterPreviewNode{Kind: "charge_point", Id: chargePoint.ChargePointId, Name: chargePoint.Name, Included: included["charge_point_"+chargePoint.ChargePointId]}
+			for _, connector := range chargePoint.Connectors {
+				chargePointNode.Children = append(chargePointNode.Children, apiserver.FilterPreviewNode{Kind: "connector", Id: connector.ConnectorId, Name: connector.EvseId, Included: included["connector_"+connector.ConnectorId]})
+			}
+			clusterNode.Children = append(clusterNode.Children, chargePointNode)
+		}
+		nodes = append(nodes, clusterNode)
+	}
+	return nodes, nil
+}
+
+// chargePointFilterProperties merges the filterable properties of the cluster, the charge point and the connector.
+// Each property is available prefixed by its level, e.g. charge_point.name or connector.plug_type, and unprefixed,
+// e.g. name. Unprefixed properties of the charge point take precedence over those of the connector, so rules written
+// for the charge point keep matching the charge point. The name of the cluster is available as cluster as well. The
+// cluster and connector are optional.
+func chargePointFilterProperties(cluster *Cluster, chargePoint *ChargePoint, connector *Connector) map[string]string {
+	properties := make(map[string]string)
+	if cluster != nil {
+		properties["cluster"] = cluster.Name
+		properties["cluster.name"] = cluster.Name
+	}
+	if chargePoint != nil {
+		for key, value := range filterProperties(chargePoint) {
+			properties[key] = value
+			properties["charge_point."+key] = value
+		}
+	}
+	if connector != nil {
+		for key, value := range filterProperties(connector) {
+			if _, ok := properties[key]; !ok {
+				properties[key] = value
+			}
+			properties["connector."+key] = value
+		}
+	}
+	return properties
+}
+
+// filterProperties returns the fields of the struct tagged as filterable by their eliona name. Other than
+// utils.StructToMap it formats numbers by their value.
+func filterProperties(data any) map[string]string {
+	value := reflect.Indirect(reflect.ValueOf(data))
+	properties := make(map[string]string)
+	for i := 0; i < value.NumField(); i++ {
+		tagParts := strings.Split(value.Type().Field(i).Tag.Get("eliona"), ",")
+		if len(tagParts) < 2 || tagParts[1] != "filterable" {
+			continue
+		}
+		properties[tagParts[0]] = fmt.Sprint(value.Field(i).Interface())
+	}
+	return properties
+}
+
+func adheresToFilter[T any](data *T, filter [][]apiserver.FilterRule) (bool, error) {
+	return matchesFilter(filter, filterProperties(data))
+}
+
+// matchesFilter checks whether the properties adhere to the filter. The rules are combined by a logical OR on
+// the first level and by a logical AND on the second level. An empty filter matches all properties.
+func matchesFilter(filter [][]apiserver.FilterRule, properties map[string]string) (bool, error) {
+	if len(filter) == 0 {
+		return true, nil
+	}
+	for _, rules := range filter {
+		conjunction := true
+		for _, rule := range rules {
+			match, err := matchesRule(rule, properties)
+			if err != nil {
+				return false, err
+			}
+			if !match {
+				conjunction = false
+				break
+			}
+		}
+		if conjunction {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchesRule checks whether the property of the rule adheres to the rule. A property not present never
+// matches, even if the rule is negated.
+func matchesRule(rule apiserver.FilterRule, properties map[string]string) (bool, error) {
+	property, ok := properties[rule.Parameter]
+	if !ok {
+		return false, nil
+	}
+
+	var match bool
+	switch rule.Operator {
+	case "", conf.FilterOperatorRegex:
+		r, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return false, fmt.Errorf("compiling rule regexp %v: %v", rule.Regex, err)
+		}
+		match = r.MatchString(property)
+	case conf.FilterOperatorEq:
+		match = equalValues(property, rule.Value)
+	case conf.FilterOperatorIn:
+		match = slices.ContainsFunc(rule.Values, func(value string) bool {
+			return equalValues(property, value)
+		})
+	case conf.FilterOperatorGt, conf.FilterOperatorGte, conf.FilterOperatorLt, conf.FilterOperatorLte:
+		value, err := strconv.ParseFloat(rule.Value, 64)
+		if err != nil {
+			return false, fmt.Errorf("value %s of operator %s is not a number", rule.Value, rule.Operator)
+		}
+		number, err := strconv.ParseFloat(property, 64)
+		if err != nil {
+			return false, nil
+		}
+		switch rule.Operator {
+		case conf.FilterOperatorGt:
+			match = number > value
+		case conf.FilterOperatorGte:
+			match = number >= value
+		case conf.FilterOperatorLt:
+			match = number < value
+		case conf.FilterOperatorLte:
+			match = number <= value
+		}
+	default:
+		return false, fmt.Errorf("unknown filter operator %s", rule.Operator)
+	}
+	return match != rule.Negate, nil
+}
+
+// equalValues compares numbers by their value and other values as strings.
+func equalValues(property string, value string) bool {
+	propertyNumber, propertyErr := strconv.ParseFloat(property, 64)
+	valueNumber, valueErr := strconv.ParseFloat(value, 64)
+	if propertyErr == nil && valueErr == nil {
+		return propertyNumber == valueNumber
+	}
+	return property == value
+}
@@ -17,15 +17,19 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"gp-joule/apiserver"
 	"gp-joule/conf"
 	"math"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
-	"github.com/eliona-smart-building-assistant/go-eliona/utils"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
 // ROOT
@@ -38,7 +42,9 @@ type Root struct {
 }
 
 func (r *Root) GetName() string {
-	return "GP Joule"
+	return renderTemplate(nameTemplate(r.Config, "root", "GP Joule {{config_id}}"), map[string]string{
+		"config_id": configId(r.Config),
+	})
 }
 
 func (r *Root) GetDescription() string {
@@ -49,8 +55,9 @@ func (r *Root) GetAssetType() string {
 	return "gp_joule_root"
 }
 
+// GetGAI is scoped to the configuration, so several configurations can share a project.
 func (r *Root) GetGAI() string {
-	return r.GetAssetType()
+	return r.GetAssetType() + "_" + configId(r.Config)
 }
 
 func (r *Root) AdheresToFilter(filter [][]apiserver.FilterRule) (bool, error) {
@@ -62,12 +69,17 @@ func (r *Root) GetAssetID(projectID string) (*int32, error) {
 }
 
 func (r *Root) SetAssetID(assetID int32, projectID string) error {
-	if err := conf.InsertAsset(context.Background(), r.Config, projectID, r.GetGAI(), assetID, r.GetAssetType(), "", ""); err != nil {
+	if err := conf.InsertAsset(context.Background(), r.Config, projectID, r.GetGAI(), assetID, r.GetAssetType(), r.GetName(), "", r.ProviderId()); err != nil {
 		return fmt.Errorf("inserting asset to Config db: %v", err)
 	}
 	return nil
 }
 
+// ProviderId identifies the root as parent of the clusters. The root is provided by the configuration.
+func (r *Root) ProviderId() string {
+	return configId(r.Config)
+}
+
 func (r *Root) GetFunctionalChildren() []asset.FunctionalNode {
 	return make([]asset.FunctionalNode, 0)
 }
@@ -76,11 +88,35 @@ func (r *Root) GetLocationalChildren() []asset.LocationalNode {
 	locationalChildren := make([]asset.LocationalNode, 0)
 	for _, cluster := range r.Clusters {
 		cluster.Config = r.Config
+		cluster.aggregate()
 		locationalChildren = append(locationalChildren, cluster)
 	}
 	return locationalChildren
 }
 
+// ARCHIVE
+
+// Archive holds the assets disappeared from GP Joule if the removal policy is archive.
+type Archive struct {
+	Config *apiserver.Configuration
+}
+
+func (a *Archive) GetName() string {
+	return "GP Joule archive"
+}
+
+func (a *Archive) GetDescription() string {
+	return "Assets removed from GP Joule"
+}
+
+func (a *Archive) GetAssetType() string {
+	return "gp_joule_archive"
+}
+
+func (a *Archive) GetGAI() string {
+	return a.GetAssetType() + "_" + configId(a.Config)
+}
+
 // CLUSTER
 
 type Cluster struct {
@@ -88,11 +124,19 @@ type Cluster struct {
 	ChargePoints []*ChargePoint `json:"chargepoints"`
 
 	// own attributes
-	Config *apiserver.Configuration
+	Config             *apiserver.Configuration
+	ConnectorsTotal    int `eliona:"connectors_total" subtype:"status"`
+	ConnectorsOccupied int `eliona:"connectors_occupied" subtype:"status"`
+	ConnectorsFaulted  int `eliona:"connectors_faulted" subtype:"status"`
+	ActiveSessions     int `eliona:"active_sessions" subtype:"status"`
+	CurrentPower       int `eliona:"current_power" subtype:"input"`
+	CurrentEnergy      int `eliona:"current_energy" subtype:"input"`
 }
 
 func (c *Cluster) GetName() string {
-	return c.Name
+	return renderTemplate(nameTemplate(c.Config, "cluster", "{{name}}"), map[string]string{
+		"name": c.Name,
+	})
 }
 
 func (c *Cluster) GetDescription() string {
@@ -104,7 +148,7 @@ func (c *Cluster) GetAssetType() string {
 }
 
 func (c *Cluster) GetGAI() string {
-	return c.GetAssetType() + "_" + c.Name
+	return c.GetAssetType() + "_" + configId(c.Config) + "_" + c.Name
 }
 
 func (c *Cluster) AdheresToFilter(filter [][]apiserver.FilterRule) (bool, error) {
@@ -116,17 +160,109 @@ func (c *Cluster) GetAssetID(projectID string) (*int32, error) {
 }
 
 func (c *Cluster) SetAssetID(assetID int32, projectID string) error {
-	if err := conf.InsertAsset(context.Background(), c.Config, projectID, c.GetGAI(), assetID, c.GetAssetType(), "", c.Name); err != nil {
+	if err := conf.InsertAsset(context.Background(), c.Config, projectID, c.GetGAI(), assetID, c.GetAssetType(), c.GetName(), configId(c.Config), c.Name); err != nil {
 		return fmt.Errorf("inserting asset to Config db: %v", err)
 	}
 	return nil
 }
 
+// aggregate sums up the connectors and running sessions of all charge points. The connectors and the sessions are
+// counted on the same connectors, so both only include the connectors adhering to the asset filter.
+func (c *Cluster) aggregate() {
+	c.ConnectorsTotal, c.ConnectorsOccupied, c.ConnectorsFaulted = 0, 0, 0
+	c.ActiveSessions, c.CurrentPower, c.CurrentEnergy = 0, 0, 0
+	for _, chargePoint := range c.ChargePoints {
+		for _, connector := range chargePoint.Connectors {
+			c.ConnectorsTotal++
+			if connector.occupancy() == 1 {
+				c.ConnectorsOccupied++
+			}
+			if connector.isFaulted() {
+				c.ConnectorsFaulted++
+			}
+
+			session := connector.activeSession()
+			if session == nil {
+				continue
+			}
+			c.ActiveSessions++
+			c.CurrentEnergy += max(session.MeterTotal, 0)
+			c.CurrentPower += currentPower(session)
+		}
+	}
+}
+
+// powerReading is the energy of a running session read at a duration and the power derived from it.
+type powerReading struct {
+	meterTotal int
+	duration   int
+	power      int
+	readAt     time.Time
+}
+
+// powerReadingTTL is the time the reading of a session not read anymore is kept.
+const powerReadingTTL = 24 * time.Hour
+
+var powerReadings = make(map[string]powerReading)
+var powerReadingsMutex sync.Mutex
+
+// currentPower returns the charging power of the running session in W. GP Joule only reports the energy charged
+// since the start of the session, so the power is derived from the energy charged between the last two reads of
+// the session. On the first read of a session the power since its start is used.
+func currentPower(session *ChargingSession) int {
+	powerReadingsMutex.Lock()
+	defer powerReadingsMutex.Unlock()
+
+	now := time.Now()
+	for id, reading := range powerReadings {
+		if now.Sub(reading.readAt) > powerReadingTTL {
+			delete(powerReadings, id)
+		}
+	}
+
+	meterTotal := max(session.MeterTotal, 0)
+	reading, ok := powerReadings[session.Id]
+	switch {
+	case ok && session.Duration > reading.duration:
+		reading.power = max(meterTotal-reading.meterTotal, 0) * 3600 / (session.Duration - reading.duration)
+		reading.meterTotal, reading.duration = meterTotal, session.Duration
+	case ok:
+		// not read again since, keep the power
+	case session.Duration > 0:
+		reading = powerReading{meterTotal: meterTotal, duration: session.Duration, power: meterTotal * 3600 / session.Duration}
+	default:
+		reading = powerReading{meterTotal: meterTotal, duration: session.Duration}
+	}
+	reading.readAt = now
+	powerReadings[session.Id] = reading
+	return reading.power
+}
+
+// GetLocation returns the average location of all charge points with a location. It returns false if
+// no charge point has a location.
+func (c *Cluster) GetLocation() (float64, float64, bool) {
+	var latitude, longitude float64
+	var count int
+	for _, chargePoint := range c.ChargePoints {
+		if lat, long, ok := chargePoint.GetLocation(); ok {
+			latitude += lat
+			longitude += long
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	return latitude / float64(count), longitude / float64(count), true
+}
+
 func (c *Cluster) GetLocationalChildren() []asset.LocationalNode {
 	locationalChildren := make([]asset.LocationalNode, 0)
 	for _, chargingPoint := range c.ChargePoints {
 		chargingPoint.Cluster = c
 		chargingPoint.Config = c.Config
+		chargingPoint.Online = mapOnlineStatus(chargingPoint.IsOnline())
+		chargingPoint.Utilisation = chargingPoint.utilisation()
 		locationalChildren = append(locationalChildren, chargingPoint)
 	}
 	return locationalChildren
@@ -140,30 +276,49 @@ type ChargePoint struct {
 	Name                string       `json:"name" eliona:"name,filterable"`
 	NameInternal        string       `json:"name_internal" eliona:"name_internal,filterable"`
 	Status              string       `json:"status" eliona:"status" subtype:"status"`
-	CommunicationStatus int          `json:"communication_status"`
+	CommunicationStatus int          `json:"communication_status" eliona:"communication_status" subtype:"status"`
 	ConnectorsTotal     int          `json:"connectors_total" eliona:"connectors_total" subtype:"info"`
-	ConnectorsFree      int          `json:"connectors_free"`
-	ConnectorsFaulted   int          `json:"connectors_faulted"`
+	ConnectorsFree      int          `json:"connectors_free" eliona:"connectors_free" subtype:"status"`
+	ConnectorsFaulted   int          `json:"connectors_faulted" eliona:"connectors_faulted" subtype:"status"`
 	ConnectorsOccupied  int          `json:"connectors_occupied" eliona:"connectors_occupied" subtype:"status"`
 	Manufacturer        string       `json:"manufacturer" eliona:"manufacturer,filterable" subtype:"info"`
 	Model               string       `json:"model" eliona:"model,filterable" subtype:"info"`
 	Lat                 float64      `json:"lat"`
 	Long                float64      `json:"long"`
-	Street              string       `json:"street"`
-	Zip                 int          `json:"zip"`
-	City                string       `json:"city"`
+	Street              string       `json:"street" eliona:"street" subtype:"info"`
+	Zip                 int          `json:"zip" eliona:"zip" subtype:"info"`
+	City                string       `json:"city" eliona:"city,filterable" subtype:"info"`
 	CountryCode         interface{}  `json:"country_code"`
-	Country             string       `json:"country"`
+	Country             string       `json:"country" eliona:"country,filterable" subtype:"info"`
 	Error               interface{}  `json:"error"`
 	Connectors          []*Connector `json:"connectors"`
 
 	// own attributes
-	Cluster *Cluster
-	Config  *apiserver.Configuration
+	Cluster     *Cluster
+	Config      *apiserver.Configuration
+	Online      int     `eliona:"online" subtype:"status"`
+	Utilisation float64 `eliona:"utilisation" subtype:"status"`
 }
 
 func (cp *ChargePoint) GetName() string {
-	return cp.Name
+	return renderTemplate(nameTemplate(cp.Config, "charge_point", "{{name}}"), map[string]string{
+		"name":          cp.Name,
+		"name_internal": cp.NameInternal,
+		"id":            cp.ChargePointId,
+		"ocpp_id":       cp.ChargePointOcppId,
+		"cluster":       cp.clusterName(),
+		"street":        cp.Street,
+		"zip":           strconv.Itoa(cp.Zip),
+		"city":          cp.City,
+		"country":       cp.Country,
+	})
+}
+
+func (cp *ChargePoint) clusterName() string {
+	if cp.Cluster == nil {
+		return ""
+	}
+	return cp.Cluster.Name
 }
 
 func (cp *ChargePoint) GetDescription() string {
@@ -179,7 +334,16 @@ func (cp *ChargePoint) GetGAI() string {
 }
 
 func (cp *ChargePoint) AdheresToFilter(filter [][]apiserver.FilterRule) (bool, error) {
-	return adheresToFilter(cp, filter)
+	if len(cp.Connectors) == 0 {
+		return matchesFilter(filter, chargePointFilterProperties(cp.Cluster, cp, nil))
+	}
+	for _, connector := range cp.Connectors {
+		adheres, err := matchesFilter(filter, chargePointFilterProperties(cp.Cluster, cp, connector))
+		if err != nil || adheres {
+			return adheres, err
+		}
+	}
+	return false, nil
 }
 
 func (cp *ChargePoint) GetAssetID(projectID string) (*int32, error) {
@@ -187,12 +351,35 @@ func (cp *ChargePoint) GetAssetID(projectID string) (*int32, error) {
 }
 
 func (cp *ChargePoint) SetAssetID(assetID int32, projectID string) error {
-	if err := conf.InsertAsset(context.Background(), cp.Config, projectID, cp.GetGAI(), assetID, cp.GetAssetType(), cp.Cluster.GetName(), cp.ChargePointId); err != nil {
+	if err := conf.InsertAsset(context.Background(), cp.Config, projectID, cp.GetGAI(), assetID, cp.GetAssetType(), cp.GetName(), cp.Cluster.Name, cp.ChargePointId); err != nil {
 		return fmt.Errorf("inserting asset to Config db: %v", err)
 	}
 	return nil
 }
 
+// GetLocation returns the geo-coordinates of the charge point. It returns false if GP Joule doesn't
+// know the location.
+func (cp *ChargePoint) GetLocation() (float64, float64, bool) {
+	return cp.Lat, cp.Long, cp.Lat != 0 || cp.Long != 0
+}
+
+// utilisation returns the share of the connectors occupied in percent. Like the counts of the cluster, it only
+// includes the connectors adhering to the asset filter.
+func (cp *ChargePoint) utilisation() float64 {
+	var occupied int
+	for _, connector := range cp.Connectors {
+		if connector.occupancy() == 1 {
+			occupied++
+		}
+	}
+	return utilisation(occupied, len(cp.Connectors))
+}
+
+// IsOnline checks if the charge point communicates with GP Joule.
+func (cp *ChargePoint) IsOnline() bool {
+	return cp.CommunicationStatus == 1
+}
+
 func (cp *ChargePoint) GetLocationalChildren() []asset.LocationalNode {
 	locationalChildren := make([]asset.LocationalNode, 0)
 
@@ -200,13 +387,14 @@ func (cp *ChargePoint) GetLocationalChildren() []asset.LocationalNode {
 	for idx, connector := range cp.Connectors {
 		connector.ChargePoint = cp
 		connector.Config = cp.Config
-		connector.Index = idx + 1
-		if connector.ChargingSession != nil && connector.ChargingSession.MeterTotal > 0 && connector.ChargingSession.SessionStart != nil {
+		if connector.Index == 0 {
+			connector.Index = idx + 1
+		}
+		connector.Occupied = connector.occupancy()
+		if connector.activeSession() != nil {
 			connector.Duration = connector.ChargingSession.Duration
 			connector.MeterTotal = int(math.Max(float64(connector.ChargingSession.MeterTotal), 0))
-			connector.Occupied = mapOccupancyStatus(connector.Status)
-		} else {
-			connector.Occupied = mapOccupancyStatus("available")
+			connector.StateOfCharge = connector.ChargingSession.LastStateOfCharge.Ptr()
 		}
 		locationalChildren = append(locationalChildren, connector)
 	}
@@ -217,25 +405,63 @@ func (cp *ChargePoint) GetLocationalChildren() []asset.LocationalNode {
 // CONNECTOR
 
 type Connector struct {
-	ConnectorId     string           `json:"uuid"`
-	EvseId          string           `json:"evseid"`
+	ConnectorId     string           `json:"uuid" eliona:"connector_id,filterable"`
+	EvseId          string           `json:"evseid" eliona:"evse_id,filterable"`
 	Status          string           `json:"status" eliona:"status" subtype:"status"`
-	MaxPower        int              `json:"max_power" eliona:"max_power" subtype:"info"`
-	ChargePointType string           `json:"chargepoint_type"`
+	MaxPower        int              `json:"max_power" eliona:"max_power,filterable" subtype:"info"`
+	ChargePointType string           `json:"chargepoint_type" eliona:"charge_point_type,filterable"`
 	PlugType        string           `json:"plug_type" eliona:"plug_type,filterable"`
 	ChargingSession *ChargingSession `json:"charging_session"`
 
 	// own attributes
-	ChargePoint *ChargePoint
-	Config      *apiserver.Configuration
-	MeterTotal  int `eliona:"current_energy" subtype:"input"`
-	Duration    int `eliona:"current_duration" subtype:"input"`
-	Occupied    int `eliona:"occupied" subtype:"status"`
-	Index       int
+	ChargePoint   *ChargePoint
+	Config        *apiserver.Configuration
+	MeterTotal    int      `eliona:"current_energy" subtype:"input"`
+	Duration      int      `eliona:"current_duration" subtype:"input"`
+	StateOfCharge *float64 `eliona:"state_of_charge" subtype:"input"`
+	Occupied      int      `eliona:"occupied" subtype:"status"`
+	Index         int
+}
+
+// activeSession returns the running session of the connector or nil if the connector isn't charging. A session
+// just started counts as running, even if no energy was charged yet.
+func (c *Connector) activeSession() *ChargingSession {
+	if c.ChargingSession != nil && c.ChargingSession.SessionStart != nil {
+		return c.ChargingSession
+	}
+	return nil
+}
+
+// occupancy returns the occupancy status of the connector as sent in the occupied attribute. Connectors without
+// running session are available.
+func (c *Connector) occupancy() int {
+	if c.activeSession() == nil {
+		return mapOccupancyStatus("available")
+	}
+	return mapOccupancyStatus(c.Status)
+}
+
+// isFaulted checks if the connector reports a fault.
+func (c *Connector) isFaulted() bool {
+	return c.Status == "faulted"
 }
 
 func (c *Connector) GetName() string {
-	return fmt.Sprintf("%s %s %d", c.PlugType, c.ChargePointType, c.Index)
+	return renderTemplate(nameTemplate(c.Config, "connector", "{{plug_type}} {{charge_point_type}} {{index}}"), map[string]string{
+		"index":             strconv.Itoa(c.Index),
+		"evse_id":           c.EvseId,
+		"connector_id":      c.ConnectorId,
+		"plug_type":         c.PlugType,
+		"charge_point_type": c.ChargePointType,
+		"charge_point":      c.chargePointName(),
+	})
+}
+
+func (c *Connector) chargePointName() string {
+	if c.ChargePoint == nil {
+		return ""
+	}
+	return c.ChargePoint.Name
 }
 
 func (c *Connector) GetDescription() string {
@@ -251,7 +477,11 @@ func (c *Connector) GetGAI() string {
 }
 
 func (c *Connector) AdheresToFilter(filter [][]apiserver.FilterRule) (bool, error) {
-	return adheresToFilter(c, filter)
+	var cluster *Cluster
+	if c.ChargePoint != nil {
+		cluster = c.ChargePoint.Cluster
+	}
+	return matchesFilter(filter, chargePointFilterProperties(cluster, c.ChargePoint, c))
 }
 
 func (c *Connector) GetAssetID(projectID string) (*int32, error) {
@@ -259,7 +489,7 @@ func (c *Connector) GetAssetID(projectID string) (*int32, error) {
 }
 
 func (c *Connector) SetAssetID(assetID int32, projectID string) error {
-	if err := conf.InsertAsset(context.Background(), c.Config, projectID, c.GetGAI(), assetID, c.GetAssetType(), c.ChargePoint.ChargePointId, c.ConnectorId); err != nil {
+	if err := conf.InsertAsset(context.Background(), c.Config, projectID, c.GetGAI(), assetID, c.GetAssetType(), c.GetName(), c.ChargePoint.ChargePointId, c.ConnectorId); err != nil {
 		return fmt.Errorf("inserting asset to Config db: %v", err)
 	}
 	return nil
@@ -285,7 +515,13 @@ type SessionsLog struct {
 }
 
 func (cs *SessionsLog) GetName() string {
-	return fmt.Sprintf("%s session log", cs.Connector.GetName())
+	return renderTemplate(nameTemplate(cs.Config, "session_log", "{{connector}} session log"), map[string]string{
+		"connector":    cs.Connector.GetName(),
+		"index":        strconv.Itoa(cs.Connector.Index),
+		"evse_id":      cs.Connector.EvseId,
+		"connector_id": cs.Connector.ConnectorId,
+		"charge_point": cs.Connector.chargePointName(),
+	})
 }
 
 func (cs *SessionsLog) GetDescription() string {
@@ -301,7 +537,7 @@ func (cs *SessionsLog) GetGAI() string {
 }
 
 func (cs *SessionsLog) AdheresToFilter(filter [][]apiserver.FilterRule) (bool, error) {
-	return adheresToFilter(cs, filter)
+	return cs.Connector.AdheresToFilter(filter)
 }
 
 func (cs *SessionsLog) GetAssetID(projectID string) (*int32, error) {
@@ -309,7 +545,7 @@ func (cs *SessionsLog) GetAssetID(projectID string) (*int32, error) {
 }
 
 func (cs *SessionsLog) SetAssetID(assetID int32, projectID string) error {
-	if err := conf.InsertAsset(context.Background(), cs.Config, projectID, cs.GetGAI(), assetID, cs.GetAssetType(), cs.Connector.ConnectorId, ""); err != nil {
+	if err := conf.InsertAsset(context.Background(), cs.Config, projectID, cs.GetGAI(), assetID, cs.GetAssetType(), cs.GetName(), cs.Connector.ConnectorId, ""); err != nil {
 		return fmt.Errorf("inserting asset to Config db: %v", err)
 	}
 	return nil
@@ -322,24 +558,98 @@ func (cs *SessionsLog) GetLocationalChildren() []asset.LocationalNode {
 // SESSION
 
 type ChargingSession struct {
-	Id                       string      `json:"id"`
-	SessionStart             *time.Time  `json:"session_start"`
-	SessionEnd               *time.Time  `json:"session_end"`
-	Duration                 int         `json:"duration"`
-	MeterStart               int         `json:"meter_start"`
-	MeterEnd                 int         `json:"meter_end"`
-	MeterTotal               int         `json:"meter_total"`
-	ChargePointId            string      `json:"chargepoint_id"`
-	ConnectorId              string      `json:"connector_uuid"`
-	ConnectorEvse            string      `json:"connector_evse"`
-	CostsNet                 float64     `json:"costs_net"`
-	TaxAmount                float64     `json:"tax_amount"`
-	Costs                    float64     `json:"costs"`
-	Currency                 string      `json:"currency"`
-	Status                   string      `json:"status"`
-	InitialStateOfCharge     interface{} `json:"initial_state_of_charge"`
-	LastStateOfCharge        interface{} `json:"last_state_of_charge"`
-	StateOfChargeLastChanged interface{} `json:"state_of_charge_last_changed"`
+	Id                       string        `json:"id"`
+	SessionStart             *time.Time    `json:"session_start"`
+	SessionEnd               *time.Time    `json:"session_end"`
+	Duration                 int           `json:"duration"`
+	MeterStart               int           `json:"meter_start"`
+	MeterEnd                 int           `json:"meter_end"`
+	MeterTotal               int           `json:"meter_total"`
+	ChargePointId            string        `json:"chargepoint_id"`
+	ConnectorId              string        `json:"connector_uuid"`
+	ConnectorEvse            string        `json:"connector_evse"`
+	CostsNet                 float64       `json:"costs_net"`
+	TaxAmount                float64       `json:"tax_amount"`
+	Costs                    float64       `json:"costs"`
+	Currency                 string        `json:"currency"`
+	Status                   string        `json:"status"`
+	InitialStateOfCharge     StateOfCharge `json:"initial_state_of_charge"`
+	LastStateOfCharge        StateOfCharge `json:"last_state_of_charge"`
+	StateOfChargeLastChanged Timestamp     `json:"state_of_charge_last_changed"`
+}
+
+// StateOfCharge is the state of charge of a vehicle in percent. The API reports it as number, as
+// string or as null if the vehicle doesn't share it. Values that aren't a percentage are unknown.
+type StateOfCharge struct {
+	Percent float64
+	Valid   bool
+}
+
+func (s *StateOfCharge) UnmarshalJSON(data []byte) error {
+	*s = StateOfCharge{}
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		return nil
+	}
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(percent) || percent < 0 || percent > 100 {
+		log.Debug("gp-joule", "Ignoring invalid state of charge %s", data)
+		return nil
+	}
+	*s = StateOfCharge{Percent: percent, Valid: true}
+	return nil
+}
+
+func (s StateOfCharge) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.Percent)
+}
+
+// Ptr returns the state of charge or nil if it is unknown.
+func (s StateOfCharge) Ptr() *float64 {
+	if !s.Valid {
+		return nil
+	}
+	return common.Ptr(s.Percent)
+}
+
+// Timestamp is a time reported by the API in RFC 3339. Values that can't be parsed are unknown, so a single
+// malformed time doesn't fail reading the whole response.
+type Timestamp struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	*t = Timestamp{}
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Debug("gp-joule", "Ignoring invalid timestamp %s", data)
+		return nil
+	}
+	*t = Timestamp{Time: parsed, Valid: true}
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time)
+}
+
+// Ptr returns the time or nil if it is unknown.
+func (t Timestamp) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return common.Ptr(t.Time)
 }
 
 // ERROR
@@ -357,38 +667,52 @@ type ErrorNotification struct {
 
 // HELPER
 
-func adheresToFilter[T any](data *T, filter [][]apiserver.FilterRule) (bool, error) {
-	f := apiFilterToCommonFilter(filter)
-	fp, err := utils.StructToMap(data)
-	if err != nil {
-		return false, fmt.Errorf("converting struct to map: %v", err)
+// nameTemplate returns the name template of the configuration for the asset kind, or the default template.
+// The placeholders available per asset kind are listed in conf.NameTemplatePlaceholders.
+func nameTemplate(config *apiserver.Configuration, kind string, defaultTemplate string) string {
+	if config == nil || config.NameTemplates[kind] == "" {
+		return defaultTemplate
 	}
-	adheres, err := common.Filter(f, fp)
-	if err != nil {
-		return false, err
+	return config.NameTemplates[kind]
+}
+
+func configId(config *apiserver.Configuration) string {
+	if config == nil || config.Id == nil {
+		return ""
 	}
-	return adheres, nil
+	return strconv.FormatInt(*config.Id, 10)
 }
 
-func apiFilterToCommonFilter(input [][]apiserver.FilterRule) [][]common.FilterRule {
-	result := make([][]common.FilterRule, len(input))
-	for i := 0; i < len(input); i++ {
-		result[i] = make([]common.FilterRule, len(input[i]))
-		for j := 0; j < len(input[i]); j++ {
-			result[i][j] = common.FilterRule{
-				Parameter: input[i][j].Parameter,
-				Regex:     input[i][j].Regex,
-			}
-		}
+// renderTemplate replaces the placeholders {{key}} in the template with the values in a single pass, so placeholders
+// contained in the values are kept as they are.
+func renderTemplate(template string, values map[string]string) string {
+	var replacements []string
+	for key, value := range values {
+		replacements = append(replacements, "{{"+key+"}}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// utilisation returns the share of occupied connectors in percent.
+func utilisation(occupied int, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(occupied) / float64(total) * 100
+}
+
+func mapOnlineStatus(online bool) int {
+	if online {
+		return 1
 	}
-	return result
+	return 0
 }
 
 func mapOccupancyStatus(status string) int {
 	if status == "available" {
 		return 0
 	}
-	if status == "occupied" {
+	if status == "occupied" || status == "charging" {
 		return 1
 	}
 	return -1
@@ -0,0 +1,374 @@
+package model
+
+import (
+	"encoding/json"
+	"gp-joule/apiserver"
+	"gp-joule/conf"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestStateOfChargeUnmarshal(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected StateOfCharge
+	}{
+		{`{"last_state_of_charge": 80}`, StateOfCharge{Percent: 80, Valid: true}},
+		{`{"last_state_of_charge": 42.5}`, StateOfCharge{Percent: 42.5, Valid: true}},
+		{`{"last_state_of_charge": "55"}`, StateOfCharge{Percent: 55, Valid: true}},
+		{`{"last_state_of_charge": ""}`, StateOfCharge{}},
+		{`{"last_state_of_charge": null}`, StateOfCharge{}},
+		{`{}`, StateOfCharge{}},
+	}
+	for _, test := range tests {
+		var session ChargingSession
+		if err := json.Unmarshal([]byte(test.json), &session); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.json, err)
+			continue
+		}
+		if session.LastStateOfCharge != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.json, test.expected, session.LastStateOfCharge)
+		}
+	}
+}
+
+func TestStateOfChargeUnmarshalInvalid(t *testing.T) {
+	payload := `[{"id": "s-1", "meter_total": 5000, "initial_state_of_charge": "full", "last_state_of_charge": 180,
+		"state_of_charge_last_changed": "yesterday", "session_end": "2024-04-03T09:00:00Z"},
+		{"id": "s-2", "last_state_of_charge": "75", "state_of_charge_last_changed": "2024-04-03T08:59:00Z"}]`
+	var sessions []*ChargingSession
+	if err := json.Unmarshal([]byte(payload), &sessions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// invalid values are unknown, the other fields survive
+	if len(sessions) != 2 || sessions[0].Id != "s-1" || sessions[0].MeterTotal != 5000 || sessions[0].SessionEnd == nil {
+		t.Fatalf("unexpected sessions %+v", sessions)
+	}
+	if sessions[0].InitialStateOfCharge.Valid || sessions[0].LastStateOfCharge.Valid || sessions[0].StateOfChargeLastChanged.Valid {
+		t.Errorf("expected unknown state of charge, got %+v", sessions[0])
+	}
+	if !sessions[1].LastStateOfCharge.Valid || sessions[1].LastStateOfCharge.Percent != 75 || sessions[1].StateOfChargeLastChanged.Ptr() == nil {
+		t.Errorf("unexpected state of charge %+v", sessions[1])
+	}
+}
+
+func TestStateOfChargeMarshal(t *testing.T) {
+	data, err := json.Marshal(ChargingSession{LastStateOfCharge: StateOfCharge{Percent: 80, Valid: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var session ChargingSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.LastStateOfCharge.Valid || session.LastStateOfCharge.Percent != 80 || session.InitialStateOfCharge.Valid {
+		t.Errorf("unexpected session %+v", session)
+	}
+}
+
+func TestUtilisation(t *testing.T) {
+	tests := []struct {
+		occupied int
+		total    int
+		expected float64
+	}{
+		{0, 0, 0},
+		{0, 4, 0},
+		{1, 4, 25},
+		{2, 2, 100},
+	}
+	for _, test := range tests {
+		if actual := utilisation(test.occupied, test.total); actual != test.expected {
+			t.Errorf("utilisation(%d, %d): expected %v, got %v", test.occupied, test.total, test.expected, actual)
+		}
+	}
+}
+
+func TestClusterAggregate(t *testing.T) {
+	start := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+	charging := &ChargingSession{Id: "s-aggregate-1", SessionStart: &start, MeterTotal: 11000, Duration: 1800}
+	// the counts of GP Joule include connectors excluded by the asset filter
+	cluster := &Cluster{ChargePoints: []*ChargePoint{
+		{ConnectorsTotal: 3, ConnectorsOccupied: 2, Connectors: []*Connector{
+			{Status: "charging", ChargingSession: charging},
+			{Status: "occupied", ChargingSession: &ChargingSession{Id: "s-aggregate-2", SessionStart: &start}},
+			{Status: "available"},
+		}},
+		{ConnectorsTotal: 1, ConnectorsFaulted: 1, Connectors: []*Connector{
+			{Status: "faulted"},
+		}},
+	}}
+	cluster.aggregate()
+	if cluster.ConnectorsTotal != 4 || cluster.ConnectorsOccupied != 2 || cluster.ConnectorsFaulted != 1 {
+		t.Errorf("unexpected connector counts %+v", cluster)
+	}
+
+	// the session just started is running, the power of the first read is the power since the start
+	if cluster.ActiveSessions != 2 || cluster.CurrentEnergy != 11000 || cluster.CurrentPower != 22000 {
+		t.Errorf("unexpected session values %+v", cluster)
+	}
+
+	// reading the same values again keeps the power
+	cluster.aggregate()
+	if cluster.CurrentPower != 22000 {
+		t.Errorf("unexpected power %d read again", cluster.CurrentPower)
+	}
+
+	// the power is derived from the energy charged since the last read
+	charging.MeterTotal, charging.Duration = 12000, 2400
+	cluster.aggregate()
+	if cluster.CurrentPower != 6000 || cluster.CurrentEnergy != 12000 {
+		t.Errorf("unexpected session values %+v", cluster)
+	}
+}
+
+func TestConnectorName(t *testing.T) {
+	chargePoint := &ChargePoint{Name: "Parking A 1"}
+	connector := &Connector{EvseId: "DE*GPJ*E0001*1", PlugType: "CCS", ChargePointType: "DC", Index: 2, ChargePoint: chargePoint, Config: &apiserver.Configuration{}}
+	if name := connector.GetName(); name != "CCS DC 2" {
+		t.Errorf("unexpected default name %q", name)
+	}
+	connector.Config.NameTemplates = map[string]string{"connector": "{{charge_point}} {{evse_id}}"}
+	if name := connector.GetName(); name != "Parking A 1 DE*GPJ*E0001*1" {
+		t.Errorf("unexpected name %q", name)
+	}
+}
+
+func TestChargePointName(t *testing.T) {
+	chargePoint := &ChargePoint{Name: "Station 1", NameInternal: "P-A-01", Cluster: &Cluster{Name: "Parking A"}}
+	if name := chargePoint.GetName(); name != "Station 1" {
+		t.Errorf("unexpected default name %q", name)
+	}
+	chargePoint.Config = &apiserver.Configuration{NameTemplates: map[string]string{"charge_point": "{{cluster}} / {{name_internal}}"}}
+	if name := chargePoint.GetName(); name != "Parking A / P-A-01" {
+		t.Errorf("unexpected name %q", name)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	values := map[string]string{"name": "Station {{cluster}}", "cluster": "Parking A"}
+	if name := renderTemplate("{{cluster}}: {{name}}", values); name != "Parking A: Station {{cluster}}" {
+		t.Errorf("unexpected name %q", name)
+	}
+}
+
+func TestClusterGAI(t *testing.T) {
+	first := &Cluster{Name: "Parking A", Config: &apiserver.Configuration{Id: common.Ptr[int64](1)}}
+	second := &Cluster{Name: "Parking A", Config: &apiserver.Configuration{Id: common.Ptr[int64](2)}}
+	if first.GetGAI() != "gp_joule_cluster_1_Parking A" || first.GetGAI() == second.GetGAI() {
+		t.Errorf("unexpected GAIs %s and %s", first.GetGAI(), second.GetGAI())
+	}
+}
+
+func TestFilterClusters(t *testing.T) {
+	ccs := &Connector{ConnectorId: "con-1-1", PlugType: "CCS", MaxPower: 150000}
+	type2 := &Connector{ConnectorId: "con-1-2", PlugType: "Type2", MaxPower: 22000}
+	otherType2 := &Connector{ConnectorId: "con-2-1", PlugType: "Type2", MaxPower: 11000}
+	clusters := []*Cluster{
+		{Name: "Parking A", ChargePoints: []*ChargePoint{
+			{ChargePointId: "cp-1", City: "Berlin", Connectors: []*Connector{ccs, type2}},
+			{ChargePointId: "cp-2", City: "Hamburg", Connectors: []*Connector{otherType2}},
+		}},
+		{Name: "Parking B", ChargePoints: []*ChargePoint{
+			{ChargePointId: "cp-3", City: "Hamburg"},
+		}},
+	}
+
+	// connector rule keeps the charge point and cluster of the connector
+	filtered, err := FilterClusters(clusters, [][]apiserver.FilterRule{{{Parameter: "plug_type", Regex: "^CCS$"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 1 || len(filtered[0].ChargePoints) != 1 || len(filtered[0].ChargePoints[0].Connectors) != 1 || filtered[0].ChargePoints[0].Connectors[0] != ccs {
+		t.Errorf("unexpected clusters for plug type filter %+v", filtered)
+	}
+	if len(clusters[0].ChargePoints[0].Connectors) != 2 {
+		t.Errorf("filter modified the clusters passed")
+	}
+
+	// rules for different levels combined, numbers are compared by their value
+	filtered, err = FilterClusters(clusters, [][]apiserver.FilterRule{
+		{{Parameter: "cluster", Regex: "^Parking A$"}, {Parameter: "max_power", Regex: "^(11|22)000$"}},
+		{{Parameter: "city", Regex: "^Hamburg$"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 2 || len(filtered[0].ChargePoints) != 2 || filtered[0].ChargePoints[0].Connectors[0] != type2 || len(filtered[1].ChargePoints) != 1 {
+		t.Errorf("unexpected clusters for combined filter %+v", filtered)
+	}
+
+	// no filter keeps everything
+	filtered, err = FilterClusters(clusters, nil)
+	if err != nil || len(filtered) != 2 {
+		t.Errorf("unexpected clusters without filter %+v: %v", filtered, err)
+	}
+}
+
+func TestChargePointFilterProperties(t *testing.T) {
+	cluster := &Cluster{Name: "Parking A"}
+	chargePoint := &ChargePoint{ChargePointId: "cp-1", Name: "Station 1", City: "Berlin"}
+	connector := &Connector{ConnectorId: "con-1-1", PlugType: "CCS", MaxPower: 150000}
+	properties := chargePointFilterProperties(cluster, chargePoint, connector)
+
+	expected := map[string]string{
+		"cluster":                "Parking A",
+		"cluster.name":           "Parking A",
+		"name":                   "Station 1",
+		"charge_point.name":      "Station 1",
+		"id":                     "cp-1",
+		"charge_point.id":        "cp-1",
+		"plug_type":              "CCS",
+		"connector.plug_type":    "CCS",
+		"connector.connector_id": "con-1-1",
+		"connector.max_power":    "150000",
+	}
+	for key, value := range expected {
+		if properties[key] != value {
+			t.Errorf("%s: expected %q, got %q", key, value, properties[key])
+		}
+	}
+	if _, ok := properties["connector.name"]; ok {
+		t.Errorf("unexpected connector name %q", properties["connector.name"])
+	}
+
+	// rules for the levels are combined by their prefixed keys
+	filtered, err := FilterClusters([]*Cluster{{Name: "Parking A", ChargePoints: []*ChargePoint{
+		{ChargePointId: "cp-1", Name: "Station 1", Connectors: []*Connector{connector, {ConnectorId: "con-1-2", PlugType: "Type2"}}},
+	}}}, [][]apiserver.FilterRule{{{Parameter: "charge_point.name", Regex: "^Station 1$"}, {Parameter: "connector.plug_type", Regex: "^CCS$"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 1 || len(filtered[0].ChargePoints[0].Connectors) != 1 || filtered[0].ChargePoints[0].Connectors[0] != connector {
+		t.Errorf("unexpected clusters for prefixed filter %+v", filtered)
+	}
+}
+
+func TestChargePointUtilisation(t *testing.T) {
+	start := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+
+	// the counts of GP Joule include connectors excluded by the asset filter
+	chargePoint := &ChargePoint{ConnectorsTotal: 4, ConnectorsOccupied: 3, Connectors: []*Connector{
+		{Status: "charging", ChargingSession: &ChargingSession{Id: "s-utilisation", SessionStart: &start}},
+		{Status: "available"},
+	}}
+	cluster := &Cluster{ChargePoints: []*ChargePoint{chargePoint}}
+	cluster.GetLocationalChildren()
+	if chargePoint.Utilisation != 50 {
+		t.Errorf("unexpected utilisation %v", chargePoint.Utilisation)
+	}
+}
+
+func TestMatchesRule(t *testing.T) {
+	properties := map[string]string{"plug_type": "CCS", "max_power": "150000", "charge_point_type": "DC"}
+	tests := []struct {
+		rule     apiserver.FilterRule
+		expected bool
+	}{
+		{apiserver.FilterRule{Parameter: "plug_type", Regex: "^CC"}, true},
+		{apiserver.FilterRule{Parameter: "plug_type", Operator: "regex", Regex: "Type2"}, false},
+		{apiserver.FilterRule{Parameter: "max_power", Operator: "gte", Value: "22000"}, true},
+		{apiserver.FilterRule{Parameter: "max_power", Operator: "gt", Value: "150000"}, false},
+		{apiserver.FilterRule{Parameter: "max_power", Operator: "lte", Value: "150000"}, true},
+		{apiserver.FilterRule{Parameter: "max_power", Operator: "lt", Value: "22000"}, false},
+		{apiserver.FilterRule{Parameter: "max_power", Operator: "eq", Value: "150000.0"}, true},
+		{apiserver.FilterRule{Parameter: "plug_type", Operator: "gt", Value: "1"}, false},
+		{apiserver.FilterRule{Parameter: "charge_point_type", Operator: "eq", Value: "AC", Negate: true}, true},
+		{apiserver.FilterRule{Parameter: "plug_type", Operator: "in", Values: []string{"CCS", "CHAdeMO"}}, true},
+		{apiserver.FilterRule{Parameter: "plug_type", Operator: "in", Values: []string{"CCS"}, Negate: true}, false},
+		{apiserver.FilterRule{Parameter: "city", Operator: "eq", Value: "Berlin", Negate: true}, false},
+	}
+	for _, test := range tests {
+		match, err := matchesRule(test.rule, properties)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", test.rule, err)
+		}
+		if match != test.expected {
+			t.Errorf("%+v: expected %t, got %t", test.rule, test.expected, match)
+		}
+	}
+
+	if _, err := matchesRule(apiserver.FilterRule{Parameter: "plug_type", Operator: "like", Value: "CCS"}, properties); err == nil {
+		t.Errorf("expected error for unknown operator")
+	}
+}
+
+func TestPreviewFilter(t *testing.T) {
+	clusters := []*Cluster{
+		{Name: "Parking A", ChargePoints: []*ChargePoint{
+			{ChargePointId: "cp-1", Name: "Station 1", Connectors: []*Connector{
+				{ConnectorId: "con-1-1", EvseId: "DE*GPJ*E0001*1", PlugType: "CCS"},
+				{ConnectorId: "con-1-2", EvseId: "DE*GPJ*E0001*2", PlugType: "Type2"},
+			}},
+			{ChargePointId: "cp-2", Name: "Station 2", Connectors: []*Connector{
+				{ConnectorId: "con-2-1", EvseId: "DE*GPJ*E0002*1", PlugType: "Type2"},
+			}},
+		}},
+	}
+
+	nodes, err := PreviewFilter(clusters, [][]apiserver.FilterRule{{{Parameter: "plug_type", Operator: "eq", Value: "CCS"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || !nodes[0].Included || len(nodes[0].Children) != 2 {
+		t.Fatalf("unexpected clusters %+v", nodes)
+	}
+	chargePoint, otherChargePoint := nodes[0].Children[0], nodes[0].Children[1]
+	if !chargePoint.Included || chargePoint.Name != "Station 1" || len(chargePoint.Children) != 2 || !chargePoint.Children[0].Included || chargePoint.Children[1].Included {
+		t.Errorf("unexpected charge point %+v", chargePoint)
+	}
+	if otherChargePoint.Included || len(otherChargePoint.Children) != 1 || otherChargePoint.Children[0].Included || otherChargePoint.Children[0].Name != "DE*GPJ*E0002*1" {
+		t.Errorf("unexpected charge point %+v", otherChargePoint)
+	}
+}
+
+func TestErrorCatalogue(t *testing.T) {
+	config := &apiserver.Configuration{
+		ErrorLanguage: common.Ptr("de"),
+		ErrorCodes: []apiserver.ErrorCode{
+			{Code: "GroundFailure", Severity: conf.ErrorSeverityMedium},
+			{Code: "OtherError", Translation: map[string]string{"de": "Störung"}},
+			{Code: "0x1F", Severity: conf.ErrorSeverityLow, Translation: map[string]string{"en": "Backend unreachable"}},
+		},
+	}
+	catalogue := NewErrorCatalogue(config)
+	tests := []struct {
+		errorCode  string
+		vendorCode string
+		text       string
+		severity   string
+	}{
+		{"HighTemperature", "", "Übertemperatur", conf.ErrorSeverityHigh},
+		{"GroundFailure", "", "Erdschluss", conf.ErrorSeverityMedium},
+		{"OtherError", "", "Störung", conf.ErrorSeverityMedium},
+		{"OtherError", "0x1F", "Verbindung zum Backend unterbrochen", conf.ErrorSeverityLow},
+		{"OtherError", "0x2A", "Störung", conf.ErrorSeverityMedium},
+		{"NewError", "", "NewError", conf.ErrorSeverityMedium},
+	}
+	for _, test := range tests {
+		if text := catalogue.Text(test.errorCode, test.vendorCode); text != test.text {
+			t.Errorf("%s/%s: expected text %s, got %s", test.errorCode, test.vendorCode, test.text, text)
+		}
+		if severity := catalogue.Severity(test.errorCode, test.vendorCode); severity != test.severity {
+			t.Errorf("%s/%s: expected severity %s, got %s", test.errorCode, test.vendorCode, test.severity, severity)
+		}
+	}
+
+	// the built-in catalogue is not changed by the configuration
+	if text := NewErrorCatalogue(nil).Text("OtherError", ""); text != "Other error" {
+		t.Errorf("unexpected built-in text %s", text)
+	}
+}
+
+func TestErrorCatalogueVendorCode(t *testing.T) {
+	catalogue := NewErrorCatalogue(nil)
+	if text := catalogue.Text("OtherError", "0x1F"); text != "Lost connection to backend" {
+		t.Errorf("unexpected text %s", text)
+	}
+	if severity := catalogue.Severity("OtherError", "0x1F"); severity != conf.ErrorSeverityMedium {
+		t.Errorf("unexpected severity %s", severity)
+	}
+}
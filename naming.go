@@ -0,0 +1,153 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/conf"
+	"gp-joule/eliona"
+	"gp-joule/model"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// assignConnectorIndices sets the index of all connectors. Connectors keep the index stored before, so
+// their names don't change if GP Joule reorders them. It returns the connectors with a new index.
+func assignConnectorIndices(config *apiserver.Configuration, clusters []*model.Cluster) ([]*model.Connector, error) {
+	var assigned []*model.Connector
+	for _, cluster := range clusters {
+		for _, chargePoint := range cluster.ChargePoints {
+			stored, err := conf.GetConnectorIndices(context.Background(), config, chargePoint.ChargePointId)
+			if err != nil {
+				return nil, err
+			}
+			assigned = append(assigned, assignIndices(chargePoint.Connectors, stored)...)
+		}
+	}
+	return assigned, nil
+}
+
+// assignIndices sets the stored index for known connectors. New connectors get their position if still
+// free, otherwise the lowest free index. It returns the connectors with a new index.
+func assignIndices(connectors []*model.Connector, stored map[string]int) []*model.Connector {
+	used := make(map[int]bool)
+	for _, index := range stored {
+		used[index] = true
+	}
+
+	var assigned []*model.Connector
+	for idx, connector := range connectors {
+		if index, ok := stored[connector.ConnectorId]; ok {
+			connector.Index = index
+			continue
+		}
+		index := idx + 1
+		if used[index] {
+			index = 1
+			for used[index] {
+				index++
+			}
+		}
+		connector.Index = index
+		used[index] = true
+		assigned = append(assigned, connector)
+	}
+	return assigned
+}
+
+// storeConnectorIndices stores the index of the connectors for their assets.
+func storeConnectorIndices(config *apiserver.Configuration, connectors []*model.Connector) error {
+	for _, connector := range connectors {
+		if err := conf.SetConnectorIndex(context.Background(), config, connector.ConnectorId, connector.Index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameAssets records the names of the assets of the project according to the name templates. Assets whose name
+// differs from the name recorded before are renamed if the configuration asks for it. Removed assets are skipped.
+func renameAssets(config *apiserver.Configuration, projectId string, root *model.Root) error {
+	names := make(map[string]string)
+	collectNames(root, names)
+
+	rename := config.RenameAssets != nil && *config.RenameAssets
+	dbAssets, err := conf.GetAssets(context.Background(), config, projectId)
+	if err != nil {
+		return err
+	}
+	for _, dbAsset := range dbAssets {
+		name, ok := names[dbAsset.GlobalAssetID]
+		if !ok || dbAsset.RemovedAt.Valid {
+			continue
+		}
+		record, renameAsset := nameUpdate(dbAsset, name, rename)
+		if !record {
+			continue
+		}
+
+		if renameAsset {
+
+			// check if asset still exists in Eliona
+			exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				continue
+			}
+
+			if err := eliona.RenameAsset(dbAsset.AssetID.Int32, name); err != nil {
+				return err
+			}
+			log.Debug("eliona", "Renamed asset %d to %s", dbAsset.AssetID.Int32, name)
+		}
+
+		// remember name
+		dbAsset.Name = null.StringFrom(name)
+		_, err = dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.Name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nameUpdate checks if the name of the asset has to be recorded and if the asset has to be renamed in Eliona. Assets
+// recorded without name were created before names were recorded and still have the name they were created with, so
+// their name is only recorded.
+func nameUpdate(dbAsset *appdb.Asset, name string, rename bool) (record bool, renameAsset bool) {
+	if !dbAsset.Name.Valid {
+		return true, false
+	}
+	if dbAsset.Name.String == name || !rename {
+		return false, false
+	}
+	return true, true
+}
+
+// collectNames maps the GAI of the node and all its locational descendants to their name.
+func collectNames(node asset.LocationalNode, names map[string]string) {
+	names[node.GetGAI()] = node.GetName()
+	for _, child := range node.GetLocationalChildren() {
+		collectNames(child, names)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/volatiletech/null/v8"
+	"gp-joule/appdb"
+	"gp-joule/model"
+	"testing"
+)
+
+func TestAssignIndices(t *testing.T) {
+	connectors := []*model.Connector{{ConnectorId: "con-a"}, {ConnectorId: "con-b"}, {ConnectorId: "con-c"}}
+
+	// con-b was the first connector before
+	assigned := assignIndices(connectors, map[string]int{"con-b": 1})
+	expected := map[string]int{"con-a": 2, "con-b": 1, "con-c": 3}
+	for _, connector := range connectors {
+		if connector.Index != expected[connector.ConnectorId] {
+			t.Errorf("%s: expected index %d, got %d", connector.ConnectorId, expected[connector.ConnectorId], connector.Index)
+		}
+	}
+	if len(assigned) != 2 || assigned[0].ConnectorId != "con-a" || assigned[1].ConnectorId != "con-c" {
+		t.Errorf("unexpected assigned connectors %v", assigned)
+	}
+}
+
+func TestNameUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		recorded    null.String
+		rename      bool
+		record      bool
+		renameAsset bool
+	}{
+		{"unrecorded", null.String{}, true, true, false},
+		{"unchanged", null.StringFrom("Station 1"), true, false, false},
+		{"changed", null.StringFrom("Station 0"), true, true, true},
+		{"changed without renaming", null.StringFrom("Station 0"), false, false, false},
+	}
+	for _, test := range tests {
+		record, renameAsset := nameUpdate(&appdb.Asset{Name: test.recorded}, "Station 1", test.rename)
+		if record != test.record || renameAsset != test.renameAsset {
+			t.Errorf("%s: expected (%t, %t), got (%t, %t)", test.name, test.record, test.renameAsset, record, renameAsset)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/conf"
+	"gp-joule/model"
+	"slices"
+)
+
+// mapClustersToProjects assigns the clusters to the projects of the configuration. A cluster is created in the projects
+// of all project mappings whose filter it matches. Clusters not matched by any mapping are created in the project
+// IDs of the configuration. All projects are returned, including those without clusters, so assets that moved to
+// another project are handled as removed.
+func mapClustersToProjects(config *apiserver.Configuration, clusters []*model.Cluster) ([]string, map[string][]*model.Cluster, error) {
+	projectIds := slices.Clone(conf.ProjIds(*config))
+	for _, mapping := range config.ProjectMappings {
+		for _, projectId := range mapping.ProjectIDs {
+			if !slices.Contains(projectIds, projectId) {
+				projectIds = append(projectIds, projectId)
+			}
+		}
+	}
+
+	assigned := make(map[string][]*model.Cluster)
+	for _, cluster := range clusters {
+		var targets []string
+		for _, mapping := range config.ProjectMappings {
+			matches, err := cluster.AdheresToFilter(mapping.Filter)
+			if err != nil {
+				return nil, nil, err
+			}
+			if matches {
+				targets = append(targets, mapping.ProjectIDs...)
+			}
+		}
+		if len(targets) == 0 {
+			targets = conf.ProjIds(*config)
+		}
+		for _, projectId := range targets {
+			if !slices.Contains(assigned[projectId], cluster) {
+				assigned[projectId] = append(assigned[projectId], cluster)
+			}
+		}
+	}
+	return projectIds, assigned, nil
+}
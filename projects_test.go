@@ -0,0 +1,54 @@
+package main
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/model"
+	"slices"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestMapClustersToProjects(t *testing.T) {
+	parkingA := &model.Cluster{Name: "Parking A"}
+	depotNorth := &model.Cluster{Name: "Depot North"}
+	other := &model.Cluster{Name: "Office"}
+	config := &apiserver.Configuration{
+		ProjectIDs: common.Ptr([]string{"1"}),
+		ProjectMappings: []apiserver.ProjectMapping{
+			{Filter: [][]apiserver.FilterRule{{{Parameter: "name", Regex: "^Parking A$"}}}, ProjectIDs: []string{"2"}},
+			{Filter: [][]apiserver.FilterRule{{{Parameter: "name", Regex: "^Depot "}}}, ProjectIDs: []string{"2", "3"}},
+		},
+	}
+
+	projectIds, clustersByProject, err := mapClustersToProjects(config, []*model.Cluster{parkingA, depotNorth, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(projectIds, []string{"1", "2", "3"}) {
+		t.Errorf("unexpected projects %v", projectIds)
+	}
+	expected := map[string][]*model.Cluster{
+		"1": {other},
+		"2": {parkingA, depotNorth},
+		"3": {depotNorth},
+	}
+	for projectId, clusters := range expected {
+		if !slices.Equal(clustersByProject[projectId], clusters) {
+			t.Errorf("project %s: unexpected clusters %v", projectId, clustersByProject[projectId])
+		}
+	}
+}
+
+func TestMapClustersToProjectsWithoutMappings(t *testing.T) {
+	parkingA := &model.Cluster{Name: "Parking A"}
+	config := &apiserver.Configuration{ProjectIDs: common.Ptr([]string{"1", "2"})}
+
+	projectIds, clustersByProject, err := mapClustersToProjects(config, []*model.Cluster{parkingA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(projectIds, []string{"1", "2"}) || len(clustersByProject["1"]) != 1 || len(clustersByProject["2"]) != 1 {
+		t.Errorf("unexpected projects %v with clusters %v", projectIds, clustersByProject)
+	}
+}
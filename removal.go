@@ -0,0 +1,276 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/conf"
+	"gp-joule/eliona"
+	"gp-joule/model"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// deleteGracePeriod is the time an asset has to be missing in GP Joule before it is deleted by the delete policy,
+// so a single incomplete response of the API doesn't delete assets.
+const deleteGracePeriod = 24 * time.Hour
+
+// handleRemovedAssets applies the removal policy of the configuration to all assets of the project that
+// disappeared from GP Joule. Assets showing up again are restored. The delete policy marks the assets as removed
+// first and deletes them once they are missing for the deleteGracePeriod. The parents map the GAIs of all assets
+// adhering to the asset filter to the GAIs of their parents, present contains the GAIs of all assets in GP
+// Joule regardless of the asset filter. Assets skipped by the asset filter are still present in GP Joule, so
+// they are kept as they are.
+func handleRemovedAssets(config *apiserver.Configuration, projectId string, root *model.Root, parents map[string]string, present map[string]string) error {
+	dbAssets, err := conf.GetAssets(context.Background(), config, projectId)
+	if err != nil {
+		return err
+	}
+	removed, restored, excluded := compareAssets(dbAssets, parents, present)
+	if len(excluded) > 0 {
+		log.Debug("eliona", "Kept %d assets skipped by the asset filter for config %d", len(excluded), *config.Id)
+	}
+	policy := removalPolicy(config)
+	var expired appdb.AssetSlice
+	if policy == conf.RemovalPolicyDelete {
+		expired = expiredAssets(dbAssets, present, time.Now().Add(-deleteGracePeriod))
+	}
+	if len(removed) == 0 && len(restored) == 0 && len(expired) == 0 {
+		return nil
+	}
+
+	archive := &model.Archive{Config: config}
+	archiveAssetId, err := conf.GetAssetId(context.Background(), config, projectId, archive.GetGAI())
+	if err != nil {
+		return err
+	}
+
+	// restore assets showing up again
+	for _, dbAsset := range restored {
+		parentAssetId, err := conf.GetAssetId(context.Background(), config, projectId, parents[dbAsset.GlobalAssetID])
+		if err != nil {
+			return err
+		}
+		exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := eliona.RestoreAsset(dbAsset.AssetID.Int32, archiveAssetId, parentAssetId); err != nil {
+				return err
+			}
+		}
+		dbAsset.RemovedAt = null.Time{}
+		if _, err := dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.RemovedAt)); err != nil {
+			return err
+		}
+		log.Info("eliona", "Restored asset %d showing up in GP Joule again", dbAsset.AssetID.Int32)
+	}
+
+	// delete assets missing for the grace period
+	for _, dbAsset := range expired {
+		exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := eliona.DeleteAsset(dbAsset.AssetID.Int32); err != nil {
+				return err
+			}
+		}
+		if _, err := dbAsset.DeleteG(context.Background()); err != nil {
+			return err
+		}
+	}
+	if len(expired) > 0 {
+		log.Info("eliona", "Deleted %d assets removed from GP Joule for config %d", len(expired), *config.Id)
+		err = eliona.NotifyUser(config.UserId, projectId, removalNotification(policy, len(expired)))
+		if err != nil {
+			log.Error("collect", "Error notifying users about deleted assets: %v", err)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	// create archive if needed
+	if policy == conf.RemovalPolicyArchive && archiveAssetId == nil {
+		archiveAssetId, err = createArchive(config, projectId, archive, root)
+		if err != nil {
+			return err
+		}
+	}
+
+	// handle removed assets, the topmost removed assets are moved to the archive, assets to delete are marked as
+	// removed until the grace period is over
+	topmost := topmostAssets(removed)
+	for _, dbAsset := range removed {
+		exists, err := asset.ExistAsset(dbAsset.AssetID.Int32)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case policy == conf.RemovalPolicyArchive && topmost[dbAsset]:
+			if exists {
+				if err := eliona.MarkAssetRemoved(dbAsset.AssetID.Int32, archiveAssetId); err != nil {
+					return err
+				}
+			}
+		default:
+			if exists {
+				if err := eliona.MarkAssetRemoved(dbAsset.AssetID.Int32, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		dbAsset.RemovedAt = null.TimeFrom(time.Now())
+		if _, err := dbAsset.UpdateG(context.Background(), boil.Whitelist(appdb.AssetColumns.RemovedAt)); err != nil {
+			return err
+		}
+	}
+	log.Info("eliona", "Handled %d assets removed from GP Joule for config %d", len(removed), *config.Id)
+
+	// send notification, assets to delete are reported when they are deleted
+	notifiedPolicy := policy
+	if policy == conf.RemovalPolicyDelete {
+		notifiedPolicy = conf.RemovalPolicyInactive
+	}
+	err = eliona.NotifyUser(config.UserId, projectId, removalNotification(notifiedPolicy, len(removed)))
+	if err != nil {
+		log.Error("collect", "Error notifying users about removed assets: %v", err)
+	}
+
+	return nil
+}
+
+// removalPolicy returns the removal policy of the configuration.
+func removalPolicy(config *apiserver.Configuration) string {
+	if config.RemovalPolicy == nil {
+		return conf.RemovalPolicyInactive
+	}
+	return *config.RemovalPolicy
+}
+
+// removalNotification returns the notification about assets removed according to the policy.
+func removalNotification(policy string, count int) *api.Translation {
+	switch policy {
+	case conf.RemovalPolicyArchive:
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("GP Joule App hat %d Assets ins Archiv verschoben, die in GP Joule nicht mehr vorhanden sind.", count)),
+			En: api.PtrString(fmt.Sprintf("GP Joule app moved %d assets no longer present in GP Joule to the archive.", count)),
+		}
+	case conf.RemovalPolicyDelete:
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("GP Joule App hat %d Assets gelöscht, die in GP Joule nicht mehr vorhanden sind.", count)),
+			En: api.PtrString(fmt.Sprintf("GP Joule app deleted %d assets no longer present in GP Joule.", count)),
+		}
+	default:
+		return &api.Translation{
+			De: api.PtrString(fmt.Sprintf("GP Joule App hat %d Assets als entfernt markiert, die in GP Joule nicht mehr vorhanden sind.", count)),
+			En: api.PtrString(fmt.Sprintf("GP Joule app marked %d assets no longer present in GP Joule as removed.", count)),
+		}
+	}
+}
+
+// compareAssets returns the assets not yet marked as removed that are missing in GP Joule, the assets marked
+// as removed that are present again, and the assets not marked as removed that are present in GP Joule but
+// skipped by the asset filter. The archive is never removed.
+func compareAssets(dbAssets appdb.AssetSlice, parents map[string]string, present map[string]string) (removed appdb.AssetSlice, restored appdb.AssetSlice, excluded appdb.AssetSlice) {
+	for _, dbAsset := range dbAssets {
+		if dbAsset.AssetType.String == (&model.Archive{}).GetAssetType() {
+			continue
+		}
+		_, adheres := parents[dbAsset.GlobalAssetID]
+		_, exists := present[dbAsset.GlobalAssetID]
+		switch {
+		case !exists && !adheres && !dbAsset.RemovedAt.Valid:
+			removed = append(removed, dbAsset)
+		case !adheres && !dbAsset.RemovedAt.Valid:
+			excluded = append(excluded, dbAsset)
+		case adheres && dbAsset.RemovedAt.Valid:
+			restored = append(restored, dbAsset)
+		}
+	}
+	return removed, restored, excluded
+}
+
+// expiredAssets returns the assets marked as removed before the time given that are still missing in GP Joule. The
+// archive is never removed.
+func expiredAssets(dbAssets appdb.AssetSlice, present map[string]string, removedBefore time.Time) (expired appdb.AssetSlice) {
+	for _, dbAsset := range dbAssets {
+		if dbAsset.AssetType.String == (&model.Archive{}).GetAssetType() {
+			continue
+		}
+		_, exists := present[dbAsset.GlobalAssetID]
+		if !exists && dbAsset.RemovedAt.Valid && dbAsset.RemovedAt.Time.Before(removedBefore) {
+			expired = append(expired, dbAsset)
+		}
+	}
+	return expired
+}
+
+// topmostAssets returns the removed assets whose parent isn't removed as well.
+func topmostAssets(removed appdb.AssetSlice) map[*appdb.Asset]bool {
+	removedProviderIds := make(map[string]bool)
+	for _, dbAsset := range removed {
+		if dbAsset.ProviderID != "" {
+			removedProviderIds[dbAsset.ProviderID] = true
+		}
+	}
+	topmost := make(map[*appdb.Asset]bool)
+	for _, dbAsset := range removed {
+		if !removedProviderIds[dbAsset.ParentProviderID] {
+			topmost[dbAsset] = true
+		}
+	}
+	return topmost
+}
+
+// createArchive creates the archive asset below the root asset of the project.
+func createArchive(config *apiserver.Configuration, projectId string, archive *model.Archive, root *model.Root) (*int32, error) {
+	rootAssetId, err := root.GetAssetID(projectId)
+	if err != nil {
+		return nil, err
+	}
+	archiveAssetId, err := asset.UpsertAsset(api.Asset{
+		ProjectId:               projectId,
+		GlobalAssetIdentifier:   archive.GetGAI(),
+		Name:                    *api.NewNullableString(common.Ptr(archive.GetName())),
+		Description:             *api.NewNullableString(common.Ptr(archive.GetDescription())),
+		AssetType:               archive.GetAssetType(),
+		ParentLocationalAssetId: *api.NewNullableInt32(rootAssetId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+	err = conf.InsertAsset(context.Background(), config, projectId, archive.GetGAI(), *archiveAssetId, archive.GetAssetType(), archive.GetName(), "", "")
+	if err != nil {
+		return nil, fmt.Errorf("inserting archive to Config db: %w", err)
+	}
+	return archiveAssetId, nil
+}
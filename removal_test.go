@@ -0,0 +1,103 @@
+package main
+
+import (
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestCompareAssets(t *testing.T) {
+	parents := map[string]string{
+		"gp_joule_root_1":            "",
+		"gp_joule_charge_point_cp-1": "gp_joule_root_1",
+	}
+	present := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-1"}
+	reappeared := &appdb.Asset{GlobalAssetID: "gp_joule_root_1", RemovedAt: null.TimeFrom(time.Now())}
+	vanished := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-2"}
+	alreadyRemoved := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-3", RemovedAt: null.TimeFrom(time.Now())}
+	archive := &appdb.Asset{GlobalAssetID: "gp_joule_archive_1", AssetType: null.StringFrom("gp_joule_archive")}
+
+	removed, restored, excluded := compareAssets(appdb.AssetSlice{present, reappeared, vanished, alreadyRemoved, archive}, parents, parents)
+	if len(removed) != 1 || removed[0] != vanished {
+		t.Errorf("unexpected removed assets %v", removed)
+	}
+	if len(restored) != 1 || restored[0] != reappeared {
+		t.Errorf("unexpected restored assets %v", restored)
+	}
+	if len(excluded) != 0 {
+		t.Errorf("unexpected excluded assets %v", excluded)
+	}
+}
+
+func TestCompareAssetsSkippedByFilter(t *testing.T) {
+	config := &apiserver.Configuration{Id: common.Ptr(int64(1))}
+	clusters := []*model.Cluster{
+		{Name: "Parking A", ChargePoints: []*model.ChargePoint{
+			{ChargePointId: "cp-1", Connectors: []*model.Connector{
+				{ConnectorId: "con-1-1", PlugType: "CCS"},
+				{ConnectorId: "con-1-2", PlugType: "Type2"},
+			}},
+		}},
+	}
+	present := make(map[string]string)
+	collectParents(&model.Root{Config: config, Clusters: clusters}, "", present)
+
+	// the connector was created before the filter excluded it
+	filtered, err := model.FilterClusters(clusters, [][]apiserver.FilterRule{{{Parameter: "plug_type", Regex: "^CCS$"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parents := make(map[string]string)
+	collectParents(&model.Root{Config: config, Clusters: filtered}, "", parents)
+
+	var dbAssets appdb.AssetSlice
+	for gai := range present {
+		dbAssets = append(dbAssets, &appdb.Asset{GlobalAssetID: gai})
+	}
+	removed, restored, excluded := compareAssets(dbAssets, parents, present)
+	if len(removed) != 0 || len(restored) != 0 {
+		t.Errorf("unexpected removed assets %v and restored assets %v", removed, restored)
+	}
+	if len(excluded) == 0 {
+		t.Errorf("expected the excluded connector")
+	}
+	for _, dbAsset := range excluded {
+		if _, adheres := parents[dbAsset.GlobalAssetID]; adheres || !strings.Contains(dbAsset.GlobalAssetID, "con-1-2") {
+			t.Errorf("unexpected excluded asset %s", dbAsset.GlobalAssetID)
+		}
+	}
+}
+
+func TestTopmostAssets(t *testing.T) {
+	chargePoint := &appdb.Asset{ProviderID: "cp-2", ParentProviderID: "Parking A"}
+	connector := &appdb.Asset{ProviderID: "con-2-1", ParentProviderID: "cp-2"}
+	sessionLog := &appdb.Asset{ProviderID: "", ParentProviderID: "con-2-1"}
+	otherConnector := &appdb.Asset{ProviderID: "con-1-3", ParentProviderID: "cp-1"}
+
+	topmost := topmostAssets(appdb.AssetSlice{chargePoint, connector, sessionLog, otherConnector})
+	if len(topmost) != 2 || !topmost[chargePoint] || !topmost[otherConnector] {
+		t.Errorf("unexpected topmost assets %v", topmost)
+	}
+}
+
+func TestExpiredAssets(t *testing.T) {
+	present := map[string]string{"gp_joule_charge_point_cp-1": "gp_joule_cluster_1_Parking A"}
+	now := time.Now()
+	expired := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-2", RemovedAt: null.TimeFrom(now.Add(-48 * time.Hour))}
+	recentlyRemoved := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-3", RemovedAt: null.TimeFrom(now.Add(-time.Hour))}
+	missing := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-4"}
+	presentAgain := &appdb.Asset{GlobalAssetID: "gp_joule_charge_point_cp-1", RemovedAt: null.TimeFrom(now.Add(-48 * time.Hour))}
+	archive := &appdb.Asset{GlobalAssetID: "gp_joule_archive_1", AssetType: null.StringFrom("gp_joule_archive"), RemovedAt: null.TimeFrom(now.Add(-48 * time.Hour))}
+
+	// assets missing in a single response are not deleted
+	got := expiredAssets(appdb.AssetSlice{expired, recentlyRemoved, missing, presentAgain, archive}, present, now.Add(-deleteGracePeriod))
+	if len(got) != 1 || got[0] != expired {
+		t.Errorf("unexpected expired assets %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"gp-joule/conf"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+)
+
+// store is the state of the synchronization of sessions and errors. It reads and records the assets, sessions and
+// errors of the app and sends the data to Eliona. It is passed to the pipeline like the client, so tests can replace
+// the database and Eliona by an in-memory store. The assets are still created directly in Eliona by collectResources,
+// so only sending sessions and errors runs against the store.
+type store interface {
+	GetConnectors(config *apiserver.Configuration) (appdb.AssetSlice, error)
+	GetChargePoints(config *apiserver.Configuration) (appdb.AssetSlice, error)
+	GetSessionsLog(config *apiserver.Configuration, projectId string, connectorId string) (*appdb.Asset, error)
+	GetChargePoint(config *apiserver.Configuration, projectId string, chargePointId string) (*appdb.Asset, error)
+	GetFirstSessionEnd(config *apiserver.Configuration, connectorId string) (*time.Time, error)
+	GetSession(config *apiserver.Configuration, sessionId string) (*appdb.Session, error)
+	StoreSession(dbSession *appdb.Session) error
+	SetSessionCursor(dbConnectorAsset *appdb.Asset) error
+	SetSessionsReadUntil(dbConnectorAssets appdb.AssetSlice, readUntil time.Time) error
+	GetErrors(config *apiserver.Configuration, errorIds []string) (map[string]*appdb.Error, error)
+	GetOpenErrors(config *apiserver.Configuration, providerIds []string) (appdb.ErrorSlice, error)
+	StoreErrors(dbErrors appdb.ErrorSlice, dbAssets appdb.AssetSlice) error
+	ExistAsset(assetId int32) (bool, error)
+	UpsertData(data api.Data) error
+}
+
+// dbStore is the store of the app's database and Eliona.
+type dbStore struct{}
+
+func (dbStore) GetConnectors(config *apiserver.Configuration) (appdb.AssetSlice, error) {
+	return conf.GetConnectors(context.Background(), config)
+}
+
+func (dbStore) GetChargePoints(config *apiserver.Configuration) (appdb.AssetSlice, error) {
+	return conf.GetChargePoints(context.Background(), config)
+}
+
+func (dbStore) GetSessionsLog(config *apiserver.Configuration, projectId string, connectorId string) (*appdb.Asset, error) {
+	return conf.GetSessionsLog(context.Background(), config, projectId, connectorId)
+}
+
+func (dbStore) GetChargePoint(config *apiserver.Configuration, projectId string, chargePointId string) (*appdb.Asset, error) {
+	return conf.GetChargePoint(context.Background(), config, projectId, chargePointId)
+}
+
+func (dbStore) GetFirstSessionEnd(config *apiserver.Configuration, connectorId string) (*time.Time, error) {
+	return conf.GetFirstSessionEnd(context.Background(), config, connectorId)
+}
+
+func (dbStore) GetSession(config *apiserver.Configuration, sessionId string) (*appdb.Session, error) {
+	return conf.GetSession(context.Background(), config, sessionId)
+}
+
+func (dbStore) StoreSession(dbSession *appdb.Session) error {
+	return conf.StoreSession(context.Background(), dbSession)
+}
+
+func (dbStore) SetSessionCursor(dbConnectorAsset *appdb.Asset) error {
+	return conf.SetSessionCursor(context.Background(), dbConnectorAsset)
+}
+
+func (dbStore) SetSessionsReadUntil(dbConnectorAssets appdb.AssetSlice, readUntil time.Time) error {
+	return conf.SetSessionsReadUntil(context.Background(), dbConnectorAssets, readUntil)
+}
+
+func (dbStore) GetErrors(config *apiserver.Configuration, errorIds []string) (map[string]*appdb.Error, error) {
+	return conf.GetErrors(context.Background(), config, errorIds)
+}
+
+func (dbStore) GetOpenErrors(config *apiserver.Configuration, providerIds []string) (appdb.ErrorSlice, error) {
+	return conf.GetOpenErrors(context.Background(), config, providerIds)
+}
+
+func (dbStore) StoreErrors(dbErrors appdb.ErrorSlice, dbAssets appdb.AssetSlice) error {
+	return conf.StoreErrors(context.Background(), dbErrors, dbAssets)
+}
+
+func (dbStore) ExistAsset(assetId int32) (bool, error) {
+	return asset.ExistAsset(assetId)
+}
+
+func (dbStore) UpsertData(data api.Data) error {
+	return asset.UpsertData(data)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"gp-joule/apiserver"
+	"gp-joule/appdb"
+	"slices"
+	"sort"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+// memStore is an in-memory store for tests. It hands out copies of its records like the database, so changes
+// only persist when stored. The data sent to Eliona is collected in data.
+type memStore struct {
+	assets   map[int64]*appdb.Asset
+	sessions map[string]*appdb.Session
+	errors   map[string]*appdb.Error
+	data     []api.Data
+
+	// failData fails sending the data to Eliona if it returns true
+	failData func(data api.Data) bool
+}
+
+// newMemStore returns a store with the assets of the charge points, connectors and session logs of the fixtures.
+// The asset ID of a charge point is 10 times its number, of a connector one more per connector and of a session
+// log 100 more than its connector.
+func newMemStore() *memStore {
+	store := &memStore{
+		assets:   make(map[int64]*appdb.Asset),
+		sessions: make(map[string]*appdb.Session),
+		errors:   make(map[string]*appdb.Error),
+	}
+	add := func(assetId int32, assetType string, parentProviderId string, providerId string) {
+		store.assets[int64(assetId)] = &appdb.Asset{
+			ID:               int64(assetId),
+			ConfigurationID:  1,
+			ProjectID:        "1",
+			GlobalAssetID:    fmt.Sprintf("%s_%s", assetType, providerId),
+			ParentProviderID: parentProviderId,
+			ProviderID:       providerId,
+			AssetID:          null.Int32From(assetId),
+			AssetType:        null.StringFrom(assetType),
+			InitVersion:      1,
+		}
+	}
+	chargePoints := map[string][]string{"cp-1": {"con-1-1", "con-1-2"}, "cp-2": {"con-2-1"}}
+	for number, chargePointId := range []string{"cp-1", "cp-2"} {
+		chargePointAssetId := int32(number+1) * 10
+		add(chargePointAssetId, "gp_joule_charge_point", "", chargePointId)
+		for index, connectorId := range chargePoints[chargePointId] {
+			add(chargePointAssetId+int32(index)+1, "gp_joule_connector", chargePointId, connectorId)
+			add(chargePointAssetId+int32(index)+101, "gp_joule_session_log", connectorId, connectorId)
+		}
+	}
+	return store
+}
+
+// addProject maps all assets to another project as well. The asset IDs in the project are offset by the given number.
+func (s *memStore) addProject(projectId string, offset int32) {
+	for _, dbAsset := range s.find(func(dbAsset *appdb.Asset) bool { return dbAsset.ProjectID == "1" }) {
+		dbAsset.ID += int64(offset)
+		dbAsset.ProjectID = projectId
+		dbAsset.AssetID = null.Int32From(dbAsset.AssetID.Int32 + offset)
+		s.assets[dbAsset.ID] = dbAsset
+	}
+}
+
+// find returns copies of the assets matching, ordered by their ID.
+func (s *memStore) find(match func(dbAsset *appdb.Asset) bool) appdb.AssetSlice {
+	var found appdb.AssetSlice
+	for _, dbAsset := range s.assets {
+		if dbAsset.RemovedAt.IsZero() && match(dbAsset) {
+			dbAssetCopy := *dbAsset
+			found = append(found, &dbAssetCopy)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].ID < found[j].ID
+	})
+	return found
+}
+
+func (s *memStore) findOne(match func(dbAsset *appdb.Asset) bool) *appdb.Asset {
+	found := s.find(match)
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+func (s *memStore) GetConnectors(config *apiserver.Configuration) (appdb.AssetSlice, error) {
+	return s.find(func(dbAsset *appdb.Asset) bool {
+		return dbAsset.ConfigurationID == *config.Id && dbAsset.AssetType.String == "gp_joule_connector"
+	}), nil
+}
+
+func (s *memStore) GetChargePoints(config *apiserver.Configuration) (appdb.AssetSlice, error) {
+	return s.find(func(dbAsset *appdb.Asset) bool {
+		return dbAsset.ConfigurationID == *config.Id && dbAsset.AssetType.String == "gp_joule_charge_point"
+	}), nil
+}
+
+func (s *memStore) GetSessionsLog(config *apiserver.Configuration, projectId string, connectorId string) (*appdb.Asset, error) {
+	return s.findOne(func(dbAsset *appdb.Asset) bool {
+		return dbAsset.ConfigurationID == *config.Id && dbAsset.ProjectID == projectId &&
+			dbAsset.AssetType.String == "gp_joule_session_log" && dbAsset.ParentProviderID == connectorId
+	}), nil
+}
+
+func (s *memStore) GetChargePoint(config *apiserver.Configuration, projectId string, chargePointId string) (*appdb.Asset, error) {
+	return s.findOne(func(dbAsset *appdb.Asset) bool {
+		return dbAsset.ConfigurationID == *config.Id && dbAsset.ProjectID == projectId &&
+			dbAsset.AssetType.String == "gp_joule_charge_point" && dbAsset.ProviderID == chargePointId
+	}), nil
+}
+
+func (s *memStore) GetFirstSessionEnd(config *apiserver.Configuration, connectorId string) (*time.Time, error) {
+	var first *time.Time
+	for _, dbSession := range s.sessions {
+		if dbSession.ConfigurationID == *config.Id && dbSession.ConnectorID == connectorId && (first == nil || dbSession.SessionEnd.Before(*first)) {
+			first = &dbSession.SessionEnd
+		}
+	}
+	return first, nil
+}
+
+func (s *memStore) GetSession(config *apiserver.Configuration, sessionId string) (*appdb.Session, error) {
+	dbSession, ok := s.sessions[sessionId]
+	if !ok || dbSession.ConfigurationID != *config.Id {
+		return nil, nil
+	}
+	dbSessionCopy := *dbSession
+	dbSessionCopy.Delivered = slices.Clone(dbSession.Delivered)
+	return &dbSessionCopy, nil
+}
+
+func (s *memStore) StoreSession(dbSession *appdb.Session) error {
+	dbSessionCopy := *dbSession
+	dbSessionCopy.Delivered = slices.Clone(dbSession.Delivered)
+	s.sessions[dbSession.SessionID] = &dbSessionCopy
+	return nil
+}
+
+func (s *memStore) SetSessionCursor(dbConnectorAsset *appdb.Asset) error {
+	s.assets[dbConnectorAsset.ID].LatestSessionTS = dbConnectorAsset.LatestSessionTS
+	s.assets[dbConnectorAsset.ID].LatestSessionID = dbConnectorAsset.LatestSessionID
+	return nil
+}
+
+func (s *memStore) SetSessionsReadUntil(dbConnectorAssets appdb.AssetSlice, readUntil time.Time) error {
+	for _, dbConnectorAsset := range dbConnectorAssets {
+		dbConnectorAsset.SessionsReadUntil = readUntil
+		s.assets[dbConnectorAsset.ID].SessionsReadUntil = readUntil
+	}
+	return nil
+}
+
+func (s *memStore) GetErrors(config *apiserver.Configuration, errorIds []string) (map[string]*appdb.Error, error) {
+	byId := make(map[string]*appdb.Error)
+	for _, errorId := range errorIds {
+		if dbError, ok := s.errors[errorId]; ok && dbError.ConfigurationID == *config.Id {
+			dbErrorCopy := *dbError
+			byId[errorId] = &dbErrorCopy
+		}
+	}
+	return byId, nil
+}
+
+func (s *memStore) GetOpenErrors(config *apiserver.Configuration, providerIds []string) (appdb.ErrorSlice, error) {
+	var open appdb.ErrorSlice
+	for _, dbError := range s.errors {
+		for _, providerId := range providerIds {
+			if dbError.ConfigurationID == *config.Id && dbError.ProviderID == providerId && !dbError.ResolvedAt.Valid {
+				dbErrorCopy := *dbError
+				open = append(open, &dbErrorCopy)
+			}
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].OccurredAt.Before(open[j].OccurredAt)
+	})
+	return open, nil
+}
+
+func (s *memStore) StoreErrors(dbErrors appdb.ErrorSlice, dbAssets appdb.AssetSlice) error {
+	for _, dbError := range dbErrors {
+		dbErrorCopy := *dbError
+		s.errors[dbError.ErrorID] = &dbErrorCopy
+	}
+	for _, dbAsset := range dbAssets {
+		s.assets[dbAsset.ID].LatestErrorTS = dbAsset.LatestErrorTS
+		s.assets[dbAsset.ID].ErrorsReadUntil = dbAsset.ErrorsReadUntil
+	}
+	return nil
+}
+
+func (s *memStore) ExistAsset(assetId int32) (bool, error) {
+	_, ok := s.assets[int64(assetId)]
+	return ok, nil
+}
+
+func (s *memStore) UpsertData(data api.Data) error {
+	if s.failData != nil && s.failData(data) {
+		return fmt.Errorf("sending data to asset %d failed", data.AssetId)
+	}
+	s.data = append(s.data, data)
+	return nil
+}